@@ -0,0 +1,103 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"os"
+	"path/filepath"
+	"slices"
+
+	"github.com/IlyasYOY/monotask/extractor"
+	"github.com/IlyasYOY/monotask/internal/pkg/config"
+)
+
+// loadConfig loads the configuration from path, when path is empty the
+// configuration is looked up from scanPath upward.
+//
+// Missing configuration results in an empty one.
+func loadConfig(path string, scanPath string) (*config.Config, error) {
+	if path != "" {
+		return config.Load(path)
+	}
+
+	dir, err := filepath.Abs(scanPath)
+	if err != nil {
+		return nil, err
+	}
+	if info, err := os.Stat(dir); err == nil && !info.IsDir() {
+		dir = filepath.Dir(dir)
+	}
+
+	path, err = config.Find(dir)
+	if err != nil {
+		return nil, err
+	}
+	if path == "" {
+		return &config.Config{}, nil
+	}
+	return config.Load(path)
+}
+
+// newRegistry extends the default extractor registry with the
+// configuration languages and mappings.
+func newRegistry(cfg *config.Config) (*extractor.Registry, error) {
+	registry := extractor.DefaultRegistry()
+	for _, name := range slices.Sorted(maps.Keys(cfg.Languages)) {
+		lang := cfg.Languages[name]
+		factory := extractor.LanguageFactory(newLanguage(lang))
+		registry.Register(name, factory)
+		for _, ext := range lang.Extensions {
+			registry.RegisterExtension(ext, factory)
+		}
+		for _, filename := range lang.Filenames {
+			registry.RegisterFilename(filename, factory)
+		}
+	}
+
+	mappings := []struct {
+		names    map[string]string
+		register func(string, extractor.Factory)
+	}{
+		{cfg.Extensions, registry.RegisterExtension},
+		{cfg.Filenames, registry.RegisterFilename},
+		{cfg.Shebangs, registry.RegisterShebang},
+	}
+	for _, mapping := range mappings {
+		for _, key := range slices.Sorted(maps.Keys(mapping.names)) {
+			name := mapping.names[key]
+			factory, ok := registry.Factory(name)
+			if !ok {
+				return nil, fmt.Errorf("%s: unknown extractor %q for %q", cfg.Path, name, key)
+			}
+			mapping.register(key, factory)
+		}
+	}
+	return registry, nil
+}
+
+// newLanguage converts the configuration language definition.
+func newLanguage(lang config.Language) extractor.Language {
+	result := extractor.Language{
+		LineComments:       lang.LineComments,
+		SpacedLineComments: lang.SpacedLineComments,
+		NestedComments:     lang.NestedComments,
+	}
+	for _, block := range lang.BlockComments {
+		result.BlockComments = append(result.BlockComments, extractor.Delimiters{Start: block.Start, End: block.End})
+	}
+	for _, literal := range lang.Strings {
+		end := literal.End
+		if end == "" {
+			end = literal.Start
+		}
+		result.Strings = append(result.Strings, extractor.StringLiteral{
+			Start:      literal.Start,
+			End:        end,
+			Escape:     literal.Escape,
+			DoubledEnd: literal.DoubledEnd,
+			Multiline:  literal.Multiline,
+			Delimiter:  literal.Delimiter,
+		})
+	}
+	return result
+}
@@ -2,39 +2,298 @@ package main
 
 import (
 	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"iter"
 	"log"
+	"maps"
 	"os"
+	"os/signal"
 	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+	"syscall"
 
-	"github.com/IlyasYOY/monotask/internal/pkg/extractor"
+	"github.com/IlyasYOY/monotask/extractor"
 	"github.com/IlyasYOY/monotask/internal/pkg/output"
 )
 
+// version is reported by -version, release builds override it with:
+//
+//	go build -ldflags "-X main.version=v1.2.3" ./cmd/monotask
+var version = "dev"
+
+type printer struct {
+	// print consumes tasks as they are extracted.
+	print func(tasks iter.Seq[extractor.Task], writer io.Writer) error
+	// buffered printers need all tasks at once, so extraction errors are
+	// reported before anything is printed.
+	buffered bool
+}
+
+var printers = map[string]printer{
+	"gnu": {print: func(tasks iter.Seq[extractor.Task], writer io.Writer) error {
+		output.StreamGNUFormatTo(tasks, writer)
+		return nil
+	}},
+	"json":   {print: collected(output.PrintJSONTo), buffered: true},
+	"ndjson": {print: output.StreamNDJSONTo},
+	"sarif":  {print: collected(output.PrintSARIFTo), buffered: true},
+}
+
+// collected adapts printers of task slices to sequences.
+func collected(print func([]extractor.Task, io.Writer) error) func(iter.Seq[extractor.Task], io.Writer) error {
+	return func(tasks iter.Seq[extractor.Task], writer io.Writer) error {
+		return print(slices.Collect(tasks), writer)
+	}
+}
+
 func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run is the whole CLI: it parses args, extracts tasks and prints them.
+//
+// It returns the exit code: 0 on success, 1 on failure or policy violation
+// and 2 on bad usage. Paths which could not be scanned are reported, they
+// fail the run only in strict mode.
+func run(args []string, stdout, stderr io.Writer) int {
 	// I don't need time here:
 	// - makes testing harder,
 	// - doesn't add benefits.
-	log.SetFlags(log.Flags() &^ (log.Ldate | log.Ltime))
+	logger := log.New(stderr, "", 0)
+
+	flags := flag.NewFlagSet("monotask", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: monotask [flags] [path ...]\n\n")
+		fmt.Fprintf(flags.Output(), "Extracts tasks from files and directories, the current directory is used by default.\n\n")
+		fmt.Fprintf(flags.Output(), "Flags:\n")
+		flags.PrintDefaults()
+	}
+
+	formats := slices.Sorted(maps.Keys(printers))
+	format := flags.String("format", "gnu", "output `format`: "+strings.Join(formats, ", "))
+	outputPath := flags.String("o", "", "write output to `file` instead of stdout")
+	markers := flags.String("markers", strings.Join(extractor.DefaultMarkers, ","), "comma separated `list` of markers to extract")
+	configPath := flags.String("config", "", "project configuration `file`, looked up from the first path upward by default")
+	failOn := flags.String("fail-on", "", "comma separated `list` of task types failing the run")
+	maxTasks := flags.Int("max-tasks", -1, "fail the run when more than `n` tasks found, negative means no limit")
+	noGitignore := flags.Bool("no-gitignore", false, "do not honor .gitignore, .git/info/exclude and the global excludes file")
+	workers := flags.Int("workers", 0, "number of files extracted concurrently, GOMAXPROCS when not positive")
+	timeout := flags.Duration("timeout", 0, "stop extraction after `duration`, e.g. 30s, no limit when zero")
+	strict := flags.Bool("strict", false, "fail the run when any file or directory could not be scanned")
+	printVersion := flags.Bool("version", false, "print version and exit")
+
+	if err := flags.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+
+	if *printVersion {
+		fmt.Fprintf(stdout, "monotask %s\n", version)
+		return 0
+	}
+
+	paths := flags.Args()
+	if len(paths) == 0 {
+		paths = []string{"."}
+	}
+
+	cfg, err := loadConfig(*configPath, paths[0])
+	if err != nil {
+		logger.Printf("Error loading config: %v", err)
+		return 1
+	}
+
+	// Flags set explicitly override the configuration file.
+	setFlags := make(map[string]bool)
+	flags.Visit(func(f *flag.Flag) {
+		setFlags[f.Name] = true
+	})
+	if !setFlags["format"] && cfg.Format != "" {
+		*format = cfg.Format
+	}
+	if !setFlags["markers"] && len(cfg.Markers) > 0 {
+		*markers = strings.Join(cfg.Markers, ",")
+	}
+	if !setFlags["fail-on"] && len(cfg.Policy.FailOn) > 0 {
+		*failOn = strings.Join(cfg.Policy.FailOn, ",")
+	}
+	if !setFlags["max-tasks"] && cfg.Policy.MaxTasks != nil {
+		*maxTasks = *cfg.Policy.MaxTasks
+	}
+	if !setFlags["no-gitignore"] && cfg.Gitignore != nil {
+		*noGitignore = !*cfg.Gitignore
+	}
+	if !setFlags["workers"] && cfg.Workers > 0 {
+		*workers = cfg.Workers
+	}
+	if !setFlags["strict"] && cfg.Strict != nil {
+		*strict = *cfg.Strict
+	}
 
-	path := "."
-	if len(os.Args) > 1 {
-		path = os.Args[1]
+	printTasks, ok := printers[*format]
+	if !ok {
+		logger.Printf("Unknown output format: %s", *format)
+		return 2
 	}
 
-	absPath, err := filepath.Abs(path)
+	markerNames, err := parseMarkers(*markers)
 	if err != nil {
-		log.Printf("Error getting absolute path: %v", err)
-		os.Exit(1)
+		logger.Printf("Invalid markers: %v", err)
+		return 2
 	}
 
-	ctx := context.Background()
-	dirExtractor := extractor.NewDirectoryExtractor(absPath)
+	var failOnTypes []string
+	if *failOn != "" {
+		failOnTypes, err = parseMarkers(*failOn)
+		if err != nil {
+			logger.Printf("Invalid fail-on types: %v", err)
+			return 2
+		}
+	}
 
-	tasks, err := dirExtractor.Extract(ctx)
+	registry, err := newRegistry(cfg)
 	if err != nil {
-		log.Printf("Error extracting tasks: %v", err)
-		os.Exit(1)
+		logger.Printf("Error loading config: %v", err)
+		return 1
 	}
 
-	output.PrintGNUFormatTo(tasks, os.Stdout)
+	opts := []extractor.Option{
+		extractor.WithMarkers(markerNames...),
+		extractor.WithIgnorePatterns(cfg.Dir(), cfg.Ignore...),
+		extractor.WithGitignore(!*noGitignore),
+		extractor.WithWorkers(*workers),
+		extractor.WithRegistry(registry),
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	// The first signal cancels the scan, the next one terminates the process
+	// even if an extractor is stuck in a blocking call.
+	context.AfterFunc(ctx, stop)
+	if *timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, *timeout)
+		defer cancel()
+	}
+
+	absPaths := make([]string, 0, len(paths))
+	for _, path := range paths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			logger.Printf("Error getting absolute path: %v", err)
+			return 1
+		}
+		absPaths = append(absPaths, absPath)
+	}
+
+	// Tasks are printed as they are extracted, only counts are kept for
+	// the policy check.
+	var counts taskCounts
+	var scanErrs extractor.ScanErrors
+	var extractErr error
+	tasks := func(yield func(extractor.Task) bool) {
+		for _, path := range absPaths {
+			for task, err := range extractor.Stream(ctx, newPathExtractor(path, opts...)) {
+				var scanErr *extractor.ScanError
+				if errors.As(err, &scanErr) {
+					scanErrs = append(scanErrs, scanErr)
+					continue
+				}
+				if err != nil {
+					extractErr = err
+					return
+				}
+
+				counts.add(task)
+				if !yield(task) {
+					return
+				}
+			}
+		}
+	}
+
+	if printTasks.buffered {
+		collectedTasks := slices.Collect(tasks)
+		if extractErr != nil {
+			logger.Printf("Error extracting tasks: %v", extractErr)
+			return 1
+		}
+		tasks = slices.Values(collectedTasks)
+	}
+
+	if err := writeOutput(*outputPath, stdout, printTasks, tasks); err != nil {
+		logger.Printf("Error printing tasks: %v", err)
+		return 1
+	}
+	if extractErr != nil {
+		logger.Printf("Error extracting tasks: %v", extractErr)
+		return 1
+	}
+
+	if len(scanErrs) > 0 {
+		for _, scanErr := range scanErrs {
+			logger.Printf("Error: %v", scanErr)
+		}
+		logger.Printf("Failed to scan %d path(s)", len(scanErrs))
+		if *strict {
+			return 1
+		}
+	}
+
+	if violations := checkPolicy(counts, failOnTypes, *maxTasks); len(violations) > 0 {
+		for _, violation := range violations {
+			logger.Printf("Policy violation: %s", violation)
+		}
+		return 1
+	}
+
+	return 0
+}
+
+// writeOutput prints tasks to the file at path or to stdout when path is empty.
+func writeOutput(path string, stdout io.Writer, printTasks printer, tasks iter.Seq[extractor.Task]) error {
+	if path == "" {
+		return printTasks.print(tasks, stdout)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := printTasks.print(tasks, file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// newPathExtractor scans regular files directly and everything else as a directory.
+func newPathExtractor(path string, opts ...extractor.Option) extractor.Extractor {
+	if info, err := os.Stat(path); err == nil && info.Mode().IsRegular() {
+		return extractor.NewFileExtractor(path, opts...)
+	}
+	return extractor.NewDirectoryExtractor(path, opts...)
+}
+
+var markerRegex = regexp.MustCompile(`^\w+$`)
+
+// parseMarkers splits comma separated markers, e.g. "TODO,FIXME,HACK".
+func parseMarkers(value string) ([]string, error) {
+	var markers []string
+	for marker := range strings.SplitSeq(value, ",") {
+		marker = strings.TrimSpace(marker)
+		if !markerRegex.MatchString(marker) {
+			return nil, fmt.Errorf("marker %q must be a non-empty word", marker)
+		}
+		markers = append(markers, marker)
+	}
+	return markers, nil
 }
@@ -0,0 +1,44 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/IlyasYOY/monotask/extractor"
+)
+
+// taskCounts counts extracted tasks, so policies are checked without
+// keeping the tasks in memory.
+type taskCounts struct {
+	total  int
+	byType map[string]int
+}
+
+func (c *taskCounts) add(task extractor.Task) {
+	if c.byType == nil {
+		c.byType = make(map[string]int)
+	}
+	c.total++
+	c.byType[task.Type]++
+}
+
+// checkPolicy returns human readable policy violations.
+//
+// Types listed in failOn must not be present, maxTasks limits the total
+// number of tasks unless it is negative.
+func checkPolicy(counts taskCounts, failOn []string, maxTasks int) []string {
+	var violations []string
+
+	for _, typ := range failOn {
+		typ = strings.ToUpper(typ)
+		if count := counts.byType[typ]; count > 0 {
+			violations = append(violations, fmt.Sprintf("found %d %s task(s)", count, typ))
+		}
+	}
+
+	if maxTasks >= 0 && counts.total > maxTasks {
+		violations = append(violations, fmt.Sprintf("found %d task(s), at most %d allowed", counts.total, maxTasks))
+	}
+
+	return violations
+}
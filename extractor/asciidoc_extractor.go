@@ -0,0 +1,11 @@
+package extractor
+
+var asciiDocLanguage = Language{
+	LineComments:  []string{"//"},
+	BlockComments: []Delimiters{{Start: "////", End: "////"}},
+}
+
+// NewAsciiDocExtractor extracts tasks from // and //// comments.
+func NewAsciiDocExtractor(filePath string, opts ...Option) Extractor {
+	return NewLanguageExtractor(asciiDocLanguage, filePath, opts...)
+}
@@ -0,0 +1,63 @@
+package extractor
+
+// C-style languages differ only in string literals, comment delimiters
+// inside literals are not comments. Their messages keep the closing */ of
+// block comments.
+var (
+	cStrings = []StringLiteral{
+		{Start: `"`, End: `"`, Escape: `\`},
+		{Start: `'`, End: `'`, Escape: `\`},
+	}
+
+	cLanguage = Language{
+		LineComments:  []string{"//"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		KeepCloser:    true,
+		Strings: append([]StringLiteral{
+			// C++ raw strings: R"delimiter(...)delimiter".
+			{Start: `R"`, End: `)%s"`, Delimiter: `([^()\\\s"]{0,16})\(`, Multiline: true},
+		}, cStrings...),
+	}
+
+	goLanguage = Language{
+		LineComments:  []string{"//"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		KeepCloser:    true,
+		Strings: append([]StringLiteral{
+			{Start: "`", End: "`", Multiline: true},
+		}, cStrings...),
+	}
+
+	javaLanguage = Language{
+		LineComments:  []string{"//"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		KeepCloser:    true,
+		Strings: append([]StringLiteral{
+			// Text blocks.
+			{Start: `"""`, End: `"""`, Escape: `\`, Multiline: true},
+		}, cStrings...),
+	}
+
+	javaScriptLanguage = Language{
+		LineComments:  []string{"//"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		KeepCloser:    true,
+		Strings: append([]StringLiteral{
+			// Template literals.
+			{Start: "`", End: "`", Escape: `\`, Multiline: true},
+		}, cStrings...),
+	}
+
+	// typstLanguage has no strings: quotes in markup are plain text.
+	typstLanguage = Language{
+		LineComments:  []string{"//"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		KeepCloser:    true,
+	}
+)
+
+// NewCCommentsExtractor extracts tasks from // and /* */ comments of C and
+// C++, comment delimiters in string and character literals are ignored.
+func NewCCommentsExtractor(filePath string, opts ...Option) Extractor {
+	return NewLanguageExtractor(cLanguage, filePath, opts...)
+}
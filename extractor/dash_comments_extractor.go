@@ -0,0 +1,46 @@
+package extractor
+
+// Languages with -- line comments besides Lua.
+var (
+	sqlLanguage = Language{
+		LineComments:  []string{"--"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		Strings: []StringLiteral{
+			{Start: `'`, End: `'`, DoubledEnd: true, Multiline: true},
+			// Quoted identifiers.
+			{Start: `"`, End: `"`, DoubledEnd: true},
+			// PostgreSQL dollar quoting: $$...$$, $tag$...$tag$.
+			{Start: "$", End: "$%s$", Delimiter: `(\w*)\$`, Multiline: true},
+		},
+	}
+
+	// haskellLanguage covers Elm as well.
+	haskellLanguage = Language{
+		LineComments:   []string{"--"},
+		BlockComments:  []Delimiters{{Start: "{-", End: "-}"}},
+		NestedComments: true,
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`},
+			// Character literals, primes like x' are not literals.
+			{Start: "'", Delimiter: `(?:\\[^']+|[^\\'])'`},
+		},
+	}
+
+	adaLanguage = Language{
+		LineComments: []string{"--"},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, DoubledEnd: true},
+			// Character literals, attributes like A'First are not literals.
+			{Start: "'", Delimiter: `.'`},
+		},
+	}
+
+	vhdlLanguage = Language{
+		LineComments:  []string{"--"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, DoubledEnd: true},
+			{Start: "'", Delimiter: `.'`},
+		},
+	}
+)
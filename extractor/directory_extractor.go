@@ -0,0 +1,221 @@
+package extractor
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"iter"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+)
+
+// NewDirectoryExtractor extracts tasks from all files in the directory recursively.
+// The .git directory is always skipped.
+//
+// Files are extracted concurrently by [WithWorkers] workers, tasks are
+// streamed in the traversal order: files sorted by name, directories
+// depth-first; tasks of a file are sorted by line and column. Tasks of a
+// file are yielded as soon as it and all files before it are extracted.
+//
+// Failures of nested files and directories don't stop the extraction, they
+// are yielded as [*ScanError] at their position in the traversal order.
+func NewDirectoryExtractor(dirPath string, opts ...Option) StreamExtractor {
+	return StreamFunc(func(ctx context.Context) iter.Seq2[Task, error] {
+		return func(yield func(Task, error) bool) {
+			o := newOptions(opts)
+
+			// Ignore rules match absolute paths.
+			absPath, err := filepath.Abs(dirPath)
+			if err != nil {
+				yield(Task{}, fmt.Errorf("error getting absolute path: %w", err))
+				return
+			}
+
+			var ignores ignoreRules
+			if o.gitignore {
+				ignores, err = gitIgnoreRules(absPath)
+				if err != nil {
+					yield(Task{}, err)
+					return
+				}
+			}
+			// Explicit ignores take precedence over git ones.
+			ignores = append(ignores, o.ignores...)
+
+			// Stops the walker and workers when the consumer stops early.
+			workCtx, cancel := context.WithCancel(ctx)
+			defer cancel()
+
+			jobs := make(chan fileJob)
+			walkErr := make(chan error, 1)
+			go func() {
+				defer close(jobs)
+				index := 0
+				send := func(job fileJob) error {
+					job.index = index
+					select {
+					case jobs <- job:
+						index++
+						return nil
+					case <-workCtx.Done():
+						return contextError(workCtx, "walking", job.path)
+					}
+				}
+				walkErr <- walkDirectory(workCtx, absPath, ignores, o.gitignore, send)
+			}()
+
+			results := make(chan fileResult)
+			var wg sync.WaitGroup
+			for range o.workers {
+				wg.Go(func() {
+					for job := range jobs {
+						result := fileResult{fileJob: job}
+						if job.walkErr == nil {
+							result.tasks, result.err = extractFile(job.path, opts).Extract(workCtx)
+							slices.SortStableFunc(result.tasks, compareTaskPositions)
+						}
+						select {
+						case results <- result:
+						case <-workCtx.Done():
+							return
+						}
+					}
+				})
+			}
+			go func() {
+				wg.Wait()
+				close(results)
+			}()
+
+			// Results come in any order, they are yielded in the traversal order.
+			pending := make(map[int]fileResult)
+			next := 0
+			for result := range results {
+				pending[result.index] = result
+				for {
+					result, ok := pending[next]
+					if !ok {
+						break
+					}
+					delete(pending, next)
+					next++
+
+					if !yieldResult(ctx, result, yield) {
+						return
+					}
+				}
+			}
+
+			if err := <-walkErr; err != nil {
+				yield(Task{}, err)
+			}
+		}
+	})
+}
+
+// yieldResult yields tasks or the error of the file, it returns false when
+// the stream must stop.
+func yieldResult(ctx context.Context, result fileResult, yield func(Task, error) bool) bool {
+	switch {
+	case result.err != nil && ctx.Err() != nil:
+		// Cancellation stops the whole extraction, other failures
+		// affect only the path.
+		yield(Task{}, result.err)
+		return false
+	case result.err != nil:
+		return yield(Task{}, &ScanError{Path: result.path, Op: "extract", Err: result.err})
+	case result.walkErr != nil:
+		return yield(Task{}, result.walkErr)
+	}
+
+	for _, task := range result.tasks {
+		if !yield(task, nil) {
+			return false
+		}
+	}
+	return true
+}
+
+type fileJob struct {
+	// index is the position of the file in the traversal order.
+	index int
+	path  string
+	// walkErr is set for directories which could not be walked,
+	// they keep their position in the traversal order.
+	walkErr *ScanError
+}
+
+type fileResult struct {
+	fileJob
+	tasks []Task
+	err   error
+}
+
+func compareTaskPositions(a, b Task) int {
+	return cmp.Or(cmp.Compare(a.Line, b.Line), cmp.Compare(a.Column, b.Column))
+}
+
+// walkDirectory calls visit for every file which is not ignored.
+//
+// Only the failure to read dirPath itself, cancellation and visit errors are
+// returned, failures of subdirectories are visited as jobs with walkErr, so
+// the rest of the tree is still visited.
+func walkDirectory(ctx context.Context, dirPath string, ignores ignoreRules, gitignore bool, visit func(job fileJob) error) error {
+	if err := contextError(ctx, "walking", dirPath); err != nil {
+		return err
+	}
+
+	var gitignores ignoreRules
+	if gitignore {
+		var err error
+		gitignores, err = readIgnoreFile(filepath.Join(dirPath, gitignoreFilename), dirPath, false)
+		if err != nil {
+			return err
+		}
+	}
+
+	mtignores, err := readIgnoreFile(filepath.Join(dirPath, mtIgnoreFilename), dirPath, true)
+	if err != nil {
+		return err
+	}
+	// Rules of the nested ignore files go last, so they take precedence.
+	allIgnores := slices.Concat(ignores, gitignores, mtignores)
+
+	entries, err := os.ReadDir(dirPath)
+	if err != nil {
+		return fmt.Errorf("error reading directory: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Name() == mtIgnoreFilename || entry.Name() == gitDirname {
+			continue
+		}
+
+		fullPath := filepath.Join(dirPath, entry.Name())
+		if allIgnores.ignored(fullPath, entry.IsDir()) {
+			continue
+		}
+
+		if entry.IsDir() {
+			err := walkDirectory(ctx, fullPath, allIgnores, gitignore, visit)
+			if err != nil && ctx.Err() != nil {
+				return err
+			}
+			if err != nil {
+				scanErr := &ScanError{Path: fullPath, Op: "read directory", Err: err}
+				if err := visit(fileJob{path: fullPath, walkErr: scanErr}); err != nil {
+					return err
+				}
+			}
+		} else {
+			if err := visit(fileJob{path: fullPath}); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+const mtIgnoreFilename = ".mtignore"
@@ -0,0 +1,64 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestDirectoryExtractorCanceled(t *testing.T) {
+	dir := t.TempDir()
+	for _, name := range []string{"a.go", "b/c.go", "b/d.md"} {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("// TODO: task\n- [ ] task\n"), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	ctx, cancel := context.WithCancel(t.Context())
+	cancel()
+
+	tasks, err := NewDirectoryExtractor(dir).Extract(ctx)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("want context.Canceled, got %v", err)
+	}
+	if !strings.Contains(err.Error(), dir) {
+		t.Errorf("want error to mention %q, got %v", dir, err)
+	}
+	if tasks != nil {
+		t.Errorf("want no tasks, got %v", tasks)
+	}
+}
+
+func TestDirectoryExtractorScanErrors(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte("// TODO: task\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	broken := filepath.Join(dir, "broken.go")
+	if err := os.Symlink(filepath.Join(dir, "missing.go"), broken); err != nil {
+		t.Skipf("symlinks are not supported: %v", err)
+	}
+
+	tasks, err := NewDirectoryExtractor(dir).Extract(t.Context())
+
+	var scanErrs ScanErrors
+	if !errors.As(err, &scanErrs) {
+		t.Fatalf("want ScanErrors, got %v", err)
+	}
+	if len(scanErrs) != 1 || scanErrs[0].Path != broken || scanErrs[0].Op != "extract" {
+		t.Errorf("want single extract error for %q, got %v", broken, scanErrs)
+	}
+	if !errors.Is(err, os.ErrNotExist) {
+		t.Errorf("want error to wrap os.ErrNotExist, got %v", err)
+	}
+	if len(tasks) != 1 || tasks[0].Message != "task" {
+		t.Errorf("want partial results with a single task, got %v", tasks)
+	}
+}
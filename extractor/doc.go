@@ -0,0 +1,22 @@
+// Package extractor extracts tasks (comment markers such as TODO, see
+// [DefaultMarkers] and [WithMarkers], and unchecked markdown checkboxes) from
+// source files and directories.
+//
+// It is the library behind the monotask CLI:
+//
+//	tasks, err := extractor.NewDirectoryExtractor("./src",
+//		extractor.WithMarkers("TODO", "FIXME"),
+//		extractor.WithWorkers(4),
+//	).Extract(ctx)
+//
+// Use [StreamExtractor.Stream] to handle tasks while the tree is still being
+// scanned.
+//
+// # Compatibility
+//
+// The package follows semantic versioning of the module: exported
+// identifiers are neither removed nor changed incompatibly within a major
+// version. New options, extractors and [Task] fields might be added in
+// minor versions. Positions and messages of extracted tasks might change
+// in minor versions when an extractor learns new syntax.
+package extractor
@@ -0,0 +1,45 @@
+package extractor
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ScanError is a failure to scan a single path, the rest of the tree is
+// still scanned.
+type ScanError struct {
+	// Path is the absolute path to the file or directory.
+	Path string
+	// Op is the failed operation: "read directory" or "extract".
+	Op  string
+	Err error
+}
+
+func (e *ScanError) Error() string {
+	return fmt.Sprintf("%s %s: %v", e.Op, e.Path, e.Err)
+}
+
+func (e *ScanError) Unwrap() error {
+	return e.Err
+}
+
+// ScanErrors are returned by [NewDirectoryExtractor] along with tasks of
+// paths which were scanned successfully. Use [errors.As] to tell them apart
+// from fatal errors.
+type ScanErrors []*ScanError
+
+func (e ScanErrors) Error() string {
+	messages := make([]string, 0, len(e))
+	for _, err := range e {
+		messages = append(messages, err.Error())
+	}
+	return fmt.Sprintf("%d path(s) could not be scanned: %s", len(e), strings.Join(messages, "; "))
+}
+
+func (e ScanErrors) Unwrap() []error {
+	errs := make([]error, 0, len(e))
+	for _, err := range e {
+		errs = append(errs, err)
+	}
+	return errs
+}
@@ -0,0 +1,38 @@
+package extractor_test
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+
+	"github.com/IlyasYOY/monotask/extractor"
+)
+
+func ExampleNewDirectoryExtractor() {
+	dir, err := os.MkdirTemp("", "monotask")
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	content := "// TODO(alice): handle errors\n// FIXME: flaky\n"
+	if err := os.WriteFile(filepath.Join(dir, "main.go"), []byte(content), 0o644); err != nil {
+		log.Fatal(err)
+	}
+
+	tasks, err := extractor.NewDirectoryExtractor(dir,
+		extractor.WithMarkers("TODO", "FIXME"),
+	).Extract(context.Background())
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	for _, task := range tasks {
+		fmt.Printf("%s:%d: %s(%s): %s\n", filepath.Base(task.File), task.Line, task.Type, task.Assignee, task.Message)
+	}
+	// Output:
+	// main.go:1: TODO(alice): handle errors
+	// main.go:2: FIXME(): flaky
+}
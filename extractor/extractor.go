@@ -0,0 +1,71 @@
+package extractor
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// Task is a single marker found in a file.
+type Task struct {
+	// File is the path to the file, absolute for directory extractors.
+	File string
+	// Line is 1-based line number.
+	Line int
+	// EndLine is the last line of the message, it differs from Line when
+	// the message continues on the next comment lines.
+	EndLine int
+	// Column is 1-based column number of the comment or the marker.
+	Column int
+	// Cell is 1-based index of the notebook cell, zero for other files. Line,
+	// EndLine and Column still point into the notebook file.
+	Cell int
+	// CellLine is 1-based line number within the notebook cell.
+	CellLine int
+	// Type is the marker in upper case, e.g. TODO, or CHECKBOX for markdown
+	// checkboxes.
+	Type string
+	// Assignee is the name in parentheses after the marker: TODO(name).
+	Assignee string
+	// Message is the text after the marker.
+	Message string
+}
+
+func parseTask(matches []string, filePath string, lineNum int, column int) Task {
+	typ := strings.ToUpper(matches[1])
+	assignee := ""
+	if len(matches) > 2 && matches[2] != "" {
+		assignee = strings.Trim(matches[2], "()")
+	}
+	message := strings.TrimSpace(matches[len(matches)-1])
+	return Task{
+		File:     filePath,
+		Line:     lineNum,
+		EndLine:  lineNum,
+		Column:   column,
+		Type:     typ,
+		Assignee: assignee,
+		Message:  message,
+	}
+}
+
+// Extractor extracts tasks at once.
+type Extractor interface {
+	Extract(ctx context.Context) ([]Task, error)
+}
+
+// ExtractorFunc adapts a function to [Extractor].
+type ExtractorFunc func(ctx context.Context) ([]Task, error)
+
+func (f ExtractorFunc) Extract(ctx context.Context) ([]Task, error) {
+	return f(ctx)
+}
+
+// contextError returns the context error wrapped with the operation and the
+// path being processed, nil when the context is neither canceled nor expired.
+func contextError(ctx context.Context, op string, path string) error {
+	if err := ctx.Err(); err != nil {
+		return fmt.Errorf("%s %s: %w", op, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+package extractor
+
+import (
+	"context"
+	"iter"
+)
+
+// NewFileExtractor picks the extractor for the file in the registry, see
+// [WithRegistry]. Files without an extractor have no tasks.
+//
+// The stream of the file extractor yields tasks once the whole file is
+// extracted, any error is fatal.
+func NewFileExtractor(filePath string, opts ...Option) StreamExtractor {
+	return StreamFunc(func(ctx context.Context) iter.Seq2[Task, error] {
+		return Stream(ctx, extractFile(filePath, opts))
+	})
+}
+
+func extractFile(filePath string, opts []Option) Extractor {
+	return ExtractorFunc(func(ctx context.Context) ([]Task, error) {
+		if err := contextError(ctx, "extracting", filePath); err != nil {
+			return nil, err
+		}
+
+		o := newOptions(opts)
+		registry := o.registry
+		if registry == nil {
+			registry = defaultRegistry
+		}
+		factory, ok, err := registry.Lookup(filePath)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return []Task{}, nil
+		}
+		return factory(filePath, opts...).Extract(ctx)
+	})
+}
@@ -0,0 +1,184 @@
+package extractor
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+const (
+	gitDirname        = ".git"
+	gitignoreFilename = ".gitignore"
+)
+
+// gitIgnoreRules returns git ignore rules applied to dir before its own
+// .gitignore: the global excludes file, the repository's info/exclude and
+// .gitignore files of parent directories up to the repository root.
+//
+// When dir is not inside a repository only the global excludes file is used.
+func gitIgnoreRules(dir string) (ignoreRules, error) {
+	root, gitDir, found := findGitRepository(dir)
+	if !found {
+		root = dir
+	}
+
+	var rules ignoreRules
+	if path := globalExcludesFile(); path != "" {
+		globalRules, err := readIgnoreFile(path, root, false)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, globalRules...)
+	}
+
+	if !found {
+		return rules, nil
+	}
+
+	excludeRules, err := readIgnoreFile(filepath.Join(gitDir, "info", "exclude"), root, false)
+	if err != nil {
+		return nil, err
+	}
+	rules = append(rules, excludeRules...)
+
+	// Parents closer to dir take precedence, so they go last.
+	var parents []string
+	for parent := dir; parent != root; {
+		parent = filepath.Dir(parent)
+		parents = append(parents, parent)
+	}
+	slices.Reverse(parents)
+
+	for _, parent := range parents {
+		parentRules, err := readIgnoreFile(filepath.Join(parent, gitignoreFilename), parent, false)
+		if err != nil {
+			return nil, err
+		}
+		rules = append(rules, parentRules...)
+	}
+
+	return rules, nil
+}
+
+// findGitRepository looks for the repository containing dir.
+//
+// It returns the work tree root and the git directory, the latter differs
+// from root/.git for worktrees and submodules where .git is a file.
+func findGitRepository(dir string) (root string, gitDir string, found bool) {
+	for {
+		gitPath := filepath.Join(dir, gitDirname)
+		info, err := os.Stat(gitPath)
+		if err == nil {
+			if info.IsDir() {
+				return dir, gitPath, true
+			}
+			if linked, ok := readGitDirLink(gitPath); ok {
+				return dir, linked, true
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", "", false
+		}
+		dir = parent
+	}
+}
+
+// readGitDirLink reads the "gitdir: path" link from the .git file.
+func readGitDirLink(path string) (string, bool) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", false
+	}
+
+	gitDir, ok := strings.CutPrefix(strings.TrimSpace(string(content)), "gitdir:")
+	if !ok {
+		return "", false
+	}
+	gitDir = strings.TrimSpace(gitDir)
+	if !filepath.IsAbs(gitDir) {
+		gitDir = filepath.Join(filepath.Dir(path), gitDir)
+	}
+	return gitDir, true
+}
+
+// globalExcludesFile returns core.excludesFile from the global git
+// configuration, defaults to $XDG_CONFIG_HOME/git/ignore.
+func globalExcludesFile() string {
+	home, _ := os.UserHomeDir()
+	configHome := os.Getenv("XDG_CONFIG_HOME")
+	if configHome == "" && home != "" {
+		configHome = filepath.Join(home, ".config")
+	}
+
+	// The latter configuration takes precedence, the same as in git.
+	var configs []string
+	if configHome != "" {
+		configs = append(configs, filepath.Join(configHome, "git", "config"))
+	}
+	if home != "" {
+		configs = append(configs, filepath.Join(home, ".gitconfig"))
+	}
+
+	excludesFile := ""
+	for _, config := range configs {
+		if path, ok := readExcludesFile(config); ok {
+			excludesFile = path
+		}
+	}
+
+	if excludesFile == "" {
+		if configHome == "" {
+			return ""
+		}
+		return filepath.Join(configHome, "git", "ignore")
+	}
+
+	if rest, ok := strings.CutPrefix(excludesFile, "~/"); ok && home != "" {
+		excludesFile = filepath.Join(home, rest)
+	}
+	return excludesFile
+}
+
+// readExcludesFile reads core.excludesFile from the git configuration file.
+//
+// It understands only the subset of the format needed for the single key.
+func readExcludesFile(configPath string) (string, bool) {
+	f, err := os.Open(configPath)
+	if err != nil {
+		return "", false
+	}
+	defer f.Close()
+
+	var path string
+	var found bool
+	inCore := false
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, ";") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			section := strings.TrimSpace(strings.Trim(line, "[]"))
+			inCore = strings.EqualFold(section, "core")
+			continue
+		}
+		if !inCore {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok || !strings.EqualFold(strings.TrimSpace(key), "excludesFile") {
+			continue
+		}
+		path = strings.Trim(strings.TrimSpace(value), `"`)
+		found = true
+	}
+
+	return path, found
+}
@@ -0,0 +1,99 @@
+package extractor
+
+const (
+	perlQuotePrefix  = `(?:^|[^\w$@%\\/|])`
+	yamlScalarPrefix = `(?:^|[\[{,]|[:-]\s)\s*`
+)
+
+// Languages with # line comments, they differ in block comments and string
+// literals.
+var (
+	rubyLanguage = Language{
+		LineComments:  []string{"#"},
+		BlockComments: []Delimiters{{Start: "=begin", End: "=end", LineStart: true}},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`, Multiline: true},
+			{Start: `'`, End: `'`, Escape: `\`, Multiline: true},
+		},
+	}
+
+	// perlLanguage skips quotes right after words, sigils and regex
+	// delimiters: $' is a variable, s/'//g is a substitution. $#array is the
+	// last index of the array, not a comment.
+	perlLanguage = Language{
+		LineComments: []string{"#"},
+		Strings: []StringLiteral{
+			{Start: `$#`},
+			{Start: `"`, End: `"`, Escape: `\`, Multiline: true, Prefix: perlQuotePrefix},
+			{Start: `'`, End: `'`, Escape: `\`, Multiline: true, Prefix: perlQuotePrefix},
+		},
+	}
+
+	// yamlLanguage quotes start only scalars: don't and 5" are plain text.
+	yamlLanguage = Language{
+		LineComments:       []string{"#"},
+		SpacedLineComments: true,
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`, Multiline: true, Prefix: yamlScalarPrefix},
+			{Start: `'`, End: `'`, DoubledEnd: true, Multiline: true, Prefix: yamlScalarPrefix},
+		},
+	}
+
+	tomlLanguage = Language{
+		LineComments: []string{"#"},
+		Strings: []StringLiteral{
+			{Start: `"""`, End: `"""`, Escape: `\`, Multiline: true},
+			{Start: `'''`, End: `'''`, Multiline: true},
+			{Start: `"`, End: `"`, Escape: `\`},
+			{Start: `'`, End: `'`},
+		},
+	}
+
+	terraformLanguage = Language{
+		LineComments:  []string{"#", "//"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`},
+		},
+	}
+
+	nixLanguage = Language{
+		LineComments:  []string{"#"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`, Multiline: true},
+			// Indented strings.
+			{Start: `''`, End: `''`, Multiline: true},
+		},
+	}
+
+	rLanguage = Language{
+		LineComments: []string{"#"},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`, Multiline: true},
+			{Start: `'`, End: `'`, Escape: `\`, Multiline: true},
+		},
+	}
+
+	powerShellLanguage = Language{
+		LineComments:  []string{"#"},
+		BlockComments: []Delimiters{{Start: "<#", End: "#>"}},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: "`", Multiline: true},
+			{Start: `'`, End: `'`, DoubledEnd: true, Multiline: true},
+		},
+	}
+
+	// cmakeLanguage has bracket comments #[[ ]] with up to two = signs.
+	cmakeLanguage = Language{
+		LineComments: []string{"#"},
+		BlockComments: []Delimiters{
+			{Start: "#[[", End: "]]"},
+			{Start: "#[=[", End: "]=]"},
+			{Start: "#[==[", End: "]==]"},
+		},
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`, Multiline: true},
+		},
+	}
+)
@@ -0,0 +1,243 @@
+package extractor
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+// ignoreRule is a single gitignore-style pattern.
+type ignoreRule struct {
+	// base is the absolute directory the pattern is relative to.
+	base string
+	// negate re-includes paths matched by previous rules: !keep.go.
+	negate bool
+	// dirOnly matches only directories: build/.
+	dirOnly bool
+	// anchored patterns match the path relative to base,
+	// others match the name at any depth.
+	anchored bool
+	re       *regexp.Regexp
+}
+
+// ignoreRules are applied in order, the last matching rule wins.
+type ignoreRules []ignoreRule
+
+// ignored reports whether the absolute path is ignored.
+func (rules ignoreRules) ignored(path string, isDir bool) bool {
+	ignored := false
+	for _, rule := range rules {
+		if rule.dirOnly && !isDir {
+			continue
+		}
+
+		rel, err := filepath.Rel(rule.base, path)
+		if err != nil || rel == "." || rel == ".." || strings.HasPrefix(rel, ".."+string(filepath.Separator)) {
+			continue
+		}
+		rel = filepath.ToSlash(rel)
+
+		subject := rel
+		if !rule.anchored {
+			subject = rel[strings.LastIndex(rel, "/")+1:]
+		}
+		if rule.re.MatchString(subject) {
+			ignored = !rule.negate
+		}
+	}
+	return ignored
+}
+
+// pathIgnoreRule ignores exactly the given absolute path.
+func pathIgnoreRule(path string) ignoreRule {
+	return ignoreRule{
+		base:     filepath.Dir(path),
+		anchored: true,
+		re:       regexp.MustCompile(`^` + regexp.QuoteMeta(filepath.Base(path)) + `$`),
+	}
+}
+
+// parseIgnoreRule parses a line of an ignore file located in base.
+//
+// It follows gitignore semantics. When anchorNames is set (.mtignore files)
+// a pattern without slashes and wildcards is anchored to base (as if it
+// starts with a slash), so plain names keep matching only next to the
+// ignore file. Use **/name to match the name at any depth.
+//
+// It returns false for empty lines, comments and patterns that can't match
+// anything, as git does not reject patterns.
+func parseIgnoreRule(base string, line string, anchorNames bool) (ignoreRule, bool) {
+	pattern := trimIgnoreLine(line)
+	if pattern == "" || strings.HasPrefix(pattern, "#") {
+		return ignoreRule{}, false
+	}
+
+	rule := ignoreRule{base: base}
+	if rest, ok := strings.CutPrefix(pattern, "!"); ok {
+		rule.negate = true
+		pattern = rest
+	}
+	if rest, ok := strings.CutSuffix(pattern, "/"); ok {
+		rule.dirOnly = true
+		pattern = rest
+	}
+	if rest, ok := strings.CutPrefix(pattern, "/"); ok {
+		rule.anchored = true
+		pattern = rest
+	}
+	if strings.Contains(pattern, "/") || (anchorNames && !hasGlobMeta(pattern)) {
+		rule.anchored = true
+	}
+	if pattern == "" {
+		return ignoreRule{}, false
+	}
+
+	re, err := regexp.Compile(globToRegexp(pattern))
+	if err != nil {
+		// Reversed ranges like [z-a] and unknown classes match nothing.
+		return ignoreRule{}, false
+	}
+	rule.re = re
+	return rule, true
+}
+
+// trimIgnoreLine drops trailing spaces unless they are escaped with a
+// backslash, leading spaces are a part of the pattern.
+func trimIgnoreLine(line string) string {
+	for strings.HasSuffix(line, " ") || strings.HasSuffix(line, "\t") {
+		if strings.HasSuffix(line, "\\ ") {
+			// Unescape the space, it is a part of the name.
+			return line[:len(line)-2] + " "
+		}
+		line = line[:len(line)-1]
+	}
+	return line
+}
+
+func hasGlobMeta(pattern string) bool {
+	for i := 0; i < len(pattern); i++ {
+		switch pattern[i] {
+		case '\\':
+			i++
+		case '*', '?', '[':
+			return true
+		}
+	}
+	return false
+}
+
+// globToRegexp converts a gitignore glob to an anchored regular expression.
+func globToRegexp(pattern string) string {
+	var re strings.Builder
+	re.WriteString(`^`)
+	for i := 0; i < len(pattern); i++ {
+		atSegmentStart := i == 0 || pattern[i-1] == '/'
+		switch c := pattern[i]; {
+		case atSegmentStart && strings.HasPrefix(pattern[i:], "**/"):
+			re.WriteString(`(?:.*/)?`)
+			i += 2
+		case atSegmentStart && pattern[i:] == "**":
+			re.WriteString(`.*`)
+			i++
+		case c == '*':
+			re.WriteString(`[^/]*`)
+		case c == '?':
+			re.WriteString(`[^/]`)
+		case c == '[':
+			class, n, ok := globClass(pattern[i:])
+			if !ok {
+				re.WriteString(`\[`)
+				continue
+			}
+			re.WriteString(class)
+			i += n - 1
+		case c == '\\' && i+1 < len(pattern):
+			i++
+			re.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		default:
+			re.WriteString(regexp.QuoteMeta(pattern[i : i+1]))
+		}
+	}
+	re.WriteString(`$`)
+	return re.String()
+}
+
+// globClass converts the bracket expression at the start of the pattern to a
+// regexp character class and returns its length. A leading ] is literal,
+// POSIX classes like [:digit:] are kept. It returns false when the bracket
+// is not closed.
+func globClass(pattern string) (string, int, bool) {
+	var class strings.Builder
+	class.WriteString(`[`)
+	i := 1
+	if i < len(pattern) && (pattern[i] == '!' || pattern[i] == '^') {
+		// Classes never match the separator.
+		class.WriteString(`^/`)
+		i++
+	}
+	for first := i; i < len(pattern); {
+		switch c := pattern[i]; {
+		case c == ']' && i > first:
+			class.WriteString(`]`)
+			return class.String(), i + 1, true
+		case c == '[' && strings.HasPrefix(pattern[i+1:], ":"):
+			end := strings.Index(pattern[i+2:], ":]")
+			if end < 0 {
+				class.WriteString(`\[`)
+				i++
+				continue
+			}
+			class.WriteString(pattern[i : i+end+4])
+			i += end + 4
+		case c == '\\' && i+1 < len(pattern):
+			class.WriteString(classChar(pattern[i+1]))
+			i += 2
+		case c == '-':
+			class.WriteString(`-`)
+			i++
+		default:
+			class.WriteString(classChar(c))
+			i++
+		}
+	}
+	return "", 0, false
+}
+
+// classChar escapes ASCII punctuation, it is literal in a character class.
+func classChar(c byte) string {
+	isAlnum := 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || '0' <= c && c <= '9'
+	if c < utf8.RuneSelf && !isAlnum {
+		return `\` + string(c)
+	}
+	return string(c)
+}
+
+// readIgnoreFile reads rules from the ignore file at path, relative to base.
+// Missing file has no rules. See [parseIgnoreRule] for anchorNames.
+func readIgnoreFile(path string, base string, anchorNames bool) (ignoreRules, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	defer f.Close()
+
+	var rules ignoreRules
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if rule, ok := parseIgnoreRule(base, scanner.Text(), anchorNames); ok {
+			rules = append(rules, rule)
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	return rules, nil
+}
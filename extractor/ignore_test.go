@@ -0,0 +1,65 @@
+package extractor
+
+import "testing"
+
+func TestIgnoreRules(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		path     string
+		isDir    bool
+		want     bool
+	}{
+		{name: "plain name", patterns: []string{"a.go"}, path: "/r/a.go", want: true},
+		{name: "plain name is anchored", patterns: []string{"a.go"}, path: "/r/sub/a.go", want: false},
+		{name: "path with slash", patterns: []string{"sub/a.go"}, path: "/r/sub/a.go", want: true},
+		{name: "leading slash", patterns: []string{"/a.go"}, path: "/r/sub/a.go", want: false},
+		{name: "wildcard at any depth", patterns: []string{"*.gen.go"}, path: "/r/x/y/api.gen.go", want: true},
+		{name: "wildcard does not cross slash", patterns: []string{"sub/*.go"}, path: "/r/sub/x/a.go", want: false},
+		{name: "question mark", patterns: []string{"?.go"}, path: "/r/sub/a.go", want: true},
+		{name: "character class", patterns: []string{"[ab].go"}, path: "/r/b.go", want: true},
+		{name: "negated character class", patterns: []string{"[!ab].go"}, path: "/r/b.go", want: false},
+		{name: "posix character class", patterns: []string{"a[[:digit:]].go"}, path: "/r/a1.go", want: true},
+		{name: "posix character class in a set", patterns: []string{"a[x[:digit:]].go"}, path: "/r/ax.go", want: true},
+		{name: "leading bracket is literal", patterns: []string{"[]a].go"}, path: "/r/].go", want: true},
+		{name: "negated leading bracket", patterns: []string{"[!]a].go"}, path: "/r/].go", want: false},
+		{name: "negated leading bracket other name", patterns: []string{"[!]a].go"}, path: "/r/b.go", want: true},
+		{name: "escaped bracket in class", patterns: []string{`[\]a].go`}, path: "/r/].go", want: true},
+		{name: "unclosed bracket is literal", patterns: []string{"[a.go"}, path: "/r/[a.go", want: true},
+		{name: "reversed range matches nothing", patterns: []string{"*.go", "[z-a].go"}, path: "/r/b.go", want: true},
+		{name: "unknown posix class matches nothing", patterns: []string{"[[:nope:]].go"}, path: "/r/[[:nope:]].go", want: false},
+		{name: "leading double star", patterns: []string{"**/vendor/"}, path: "/r/x/vendor", isDir: true, want: true},
+		{name: "leading double star at root", patterns: []string{"**/vendor"}, path: "/r/vendor", want: true},
+		{name: "middle double star", patterns: []string{"a/**/b.go"}, path: "/r/a/x/y/b.go", want: true},
+		{name: "middle double star matches zero dirs", patterns: []string{"a/**/b.go"}, path: "/r/a/b.go", want: true},
+		{name: "trailing double star", patterns: []string{"a/**"}, path: "/r/a/x/b.go", want: true},
+		{name: "directory only skips files", patterns: []string{"build/"}, path: "/r/build", want: false},
+		{name: "directory only", patterns: []string{"build/"}, path: "/r/build", isDir: true, want: true},
+		{name: "negation", patterns: []string{"*.go", "!keep.go"}, path: "/r/keep.go", want: false},
+		{name: "last rule wins", patterns: []string{"!keep.go", "*.go"}, path: "/r/keep.go", want: true},
+		{name: "comment", patterns: []string{"# a.go"}, path: "/r/# a.go", want: false},
+		{name: "escaped hash", patterns: []string{`\#a.go`}, path: "/r/#a.go", want: true},
+		{name: "escaped exclamation", patterns: []string{`\!a.go`}, path: "/r/!a.go", want: true},
+		{name: "trailing spaces", patterns: []string{"a.go  "}, path: "/r/a.go", want: true},
+		{name: "leading spaces are kept", patterns: []string{" a.go"}, path: "/r/a.go", want: false},
+		{name: "leading space matches", patterns: []string{" a.go"}, path: "/r/ a.go", want: true},
+		{name: "escaped trailing space", patterns: []string{`a.go\ `}, path: "/r/a.go ", want: true},
+		{name: "outside of base", patterns: []string{"*.go"}, path: "/other/a.go", want: false},
+		{name: "regexp characters are literal", patterns: []string{"a+(b).go"}, path: "/r/a+(b).go", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var rules ignoreRules
+			for _, pattern := range tt.patterns {
+				if rule, ok := parseIgnoreRule("/r", pattern, true); ok {
+					rules = append(rules, rule)
+				}
+			}
+
+			if got := rules.ignored(tt.path, tt.isDir); got != tt.want {
+				t.Errorf("ignored(%q) = %v, want %v", tt.path, got, tt.want)
+			}
+		})
+	}
+}
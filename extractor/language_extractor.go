@@ -0,0 +1,648 @@
+package extractor
+
+import (
+	"bufio"
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+)
+
+// Language describes comment syntax of a programming language, it drives
+// [NewLanguageExtractor].
+type Language struct {
+	// LineComments start comments running to the end of the line, e.g. "//".
+	LineComments []string
+	// SpacedLineComments start only at the line start or after whitespace,
+	// as # in YAML: "url: a#b" has no comment.
+	SpacedLineComments bool
+	// BlockComments are comments between delimiters, e.g. /* and */.
+	BlockComments []Delimiters
+	// NestedComments allows block comments inside block comments.
+	NestedComments bool
+	// KeepCloser keeps the end of a block comment in the message of its
+	// last task, as C-style extractors always did: "TODO: x */".
+	KeepCloser bool
+	// Strings are literals where comment delimiters are ignored.
+	Strings []StringLiteral
+	// DocStrings are literals scanned for tasks as comments, e.g. """ in Python.
+	DocStrings []StringLiteral
+	// Escape makes the next character plain code outside of comments and
+	// literals, e.g. `\` in shell: \# and \' open nothing.
+	Escape string
+}
+
+// Delimiters are start and end of a block comment.
+type Delimiters struct {
+	Start string
+	End   string
+	// Delimiter is a regular expression matched right after Start, its first
+	// group replaces %s in End as for StringLiteral.
+	// Lua --[==[...]==] is Start `--[`, Delimiter `(=*)\[` and End `]%s]`.
+	Delimiter string
+	// LineStart delimiters count only at the start of a line, as Ruby
+	// =begin and =end.
+	LineStart bool
+}
+
+// StringLiteral describes a kind of string literal.
+type StringLiteral struct {
+	Start string
+	End   string
+	// Escape makes the next character a part of the literal, e.g. `\`.
+	Escape string
+	// DoubledEnd makes doubled End a part of the literal: 'it''s'.
+	DoubledEnd bool
+	// Multiline literals continue on the next line when not closed.
+	Multiline bool
+	// Delimiter is a regular expression matched right after Start for raw
+	// strings with custom delimiters, its first group replaces %s in End.
+	// C++ R"x(...)x" is Start `R"`, Delimiter `([^()\\\s]*)\(` and End `)%s"`.
+	Delimiter string
+	// Heredoc literals start on the next line and end at the line equal to
+	// End, leading tabs aside, as shell <<EOF. Only Strings may be heredocs.
+	Heredoc bool
+	// Prefix is a regular expression the text before Start must end with,
+	// e.g. YAML quotes start only scalars: `(?:^|[\[{,]|[:-]\s)\s*`.
+	Prefix string
+}
+
+// LanguageFactory returns a factory of extractors for the language.
+func LanguageFactory(lang Language) Factory {
+	return func(filePath string, opts ...Option) Extractor {
+		return NewLanguageExtractor(lang, filePath, opts...)
+	}
+}
+
+// NewLanguageExtractor extracts tasks from comments of the language.
+//
+// Line comments and the first line of block comments report the column of
+// the comment, other lines of block comments and docstrings report the
+// column of the marker. Every marker of a comment starts a new task:
+// "// TODO: a BUG: b" results in two tasks.
+//
+// Comment lines right after a task, indented deeper than its marker,
+// continue the task message until a blank comment line or a new marker.
+func NewLanguageExtractor(lang Language, filePath string, opts ...Option) Extractor {
+	o := newOptions(opts)
+
+	return ExtractorFunc(func(ctx context.Context) ([]Task, error) {
+		tasks, err := newTaskScanner(lang, o.markers, filePath)
+		if err != nil {
+			return nil, err
+		}
+
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(nil, 1024*1024) // Set max token size to 1MB for long lines
+		lineNum := 0
+
+		for scanner.Scan() {
+			if err := contextError(ctx, "extracting", filePath); err != nil {
+				return nil, err
+			}
+
+			lineNum++
+			tasks.scanLine(lineNum, scanner.Text(), 0)
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading file: %w", err)
+		}
+
+		return tasks.tasks, nil
+	})
+}
+
+// taskScanner collects tasks from comments of the language line by line.
+type taskScanner struct {
+	filePath          string
+	comments          *commentScanner
+	markers           *markerSet
+	inBlockRegex      *regexp.Regexp
+	docStringRegex    *regexp.Regexp
+	nextMarkerRegex   *regexp.Regexp
+	openerRegexes     map[string]*regexp.Regexp
+	nextOpenerRegexes map[string]*regexp.Regexp
+
+	tasks []Task
+	// last is the task continued by the next line, if any.
+	last *continuedTask
+}
+
+func newTaskScanner(lang Language, markers *markerSet, filePath string) (*taskScanner, error) {
+	comments, err := newCommentScanner(lang)
+	if err != nil {
+		return nil, err
+	}
+
+	s := &taskScanner{
+		filePath:          filePath,
+		comments:          comments,
+		markers:           markers,
+		inBlockRegex:      markers.regexp(`\s*%s`),
+		docStringRegex:    markers.regexp(`%s`),
+		nextMarkerRegex:   markers.headRegexp(`\b%s`),
+		openerRegexes:     make(map[string]*regexp.Regexp),
+		nextOpenerRegexes: make(map[string]*regexp.Regexp),
+	}
+	return s, nil
+}
+
+// openerRegex returns regexes of the task and of the next marker for comments
+// starting with the opener, they are compiled on demand as openers with
+// custom delimiters, e.g. --[==[, are known only when found.
+func (s *taskScanner) openerRegex(opener string) (*regexp.Regexp, *regexp.Regexp) {
+	re, ok := s.openerRegexes[opener]
+	if !ok {
+		re = s.markers.regexp(delimiterPattern(opener) + `\s*%s`)
+		s.openerRegexes[opener] = re
+		s.nextOpenerRegexes[opener] = s.markers.headRegexp(`(?:` + delimiterPattern(opener) + `\s*)?\b%s`)
+	}
+	return re, s.nextOpenerRegexes[opener]
+}
+
+// scanLine collects tasks of the line, offset is the byte offset of line
+// when it is a part of a longer line.
+func (s *taskScanner) scanLine(lineNum int, line string, offset int) {
+	previous := s.last
+	s.last = nil
+	for i, comment := range s.comments.scanLine(line) {
+		re, nextRe := s.inBlockRegex, s.nextMarkerRegex
+		switch comment.kind {
+		case lineComment, blockCommentStart:
+			re, nextRe = s.openerRegex(comment.opener)
+		case docStringLine:
+			re = s.docStringRegex
+		}
+
+		text := comment.text
+		if !s.comments.lang.KeepCloser {
+			text = strings.TrimSuffix(text, comment.closer)
+		}
+		loc := re.FindStringSubmatchIndex(text)
+		if loc == nil {
+			s.last = nil
+			if i == 0 && previous != nil {
+				if text, ok := previous.continuation(line, comment); ok {
+					task := &s.tasks[previous.index]
+					task.Message = strings.TrimSpace(task.Message + " " + text)
+					task.EndLine = lineNum
+					s.last = previous
+				}
+			}
+			continue
+		}
+		var match markerMatch
+		for _, match = range splitMarkers(text, loc, nextRe) {
+			column := offset + comment.offset + match.start + 1
+			s.tasks = append(s.tasks, parseTask(match.groups, s.filePath, lineNum, column))
+		}
+		s.last = &continuedTask{
+			index:        len(s.tasks) - 1,
+			kind:         comment.kind,
+			opener:       comment.opener,
+			markerOffset: comment.offset + match.markerStart,
+		}
+	}
+}
+
+// delimiterPattern quotes the delimiter for [markerSet.regexp] patterns.
+func delimiterPattern(delimiter string) string {
+	return strings.ReplaceAll(regexp.QuoteMeta(delimiter), "%", "%%")
+}
+
+type markerMatch struct {
+	start       int
+	markerStart int
+	groups      []string
+}
+
+// splitMarkers splits the message of the task match loc at markers found by
+// nextRe, so each marker has its own message.
+func splitMarkers(text string, loc []int, nextRe *regexp.Regexp) []markerMatch {
+	match := markerMatch{start: loc[0], markerStart: loc[2], groups: submatches(text, loc)}
+	messageStart := loc[6]
+
+	var matches []markerMatch
+	for {
+		next := nextMarker(text, messageStart, nextRe)
+		if next == nil {
+			match.groups[3] = text[messageStart:]
+			return append(matches, match)
+		}
+
+		// "TODO: a, BUG: b" separates markers with punctuation.
+		match.groups[3] = strings.TrimRight(text[messageStart:next[0]], " \t,;")
+		matches = append(matches, match)
+
+		match = markerMatch{start: next[0], markerStart: next[2], groups: append(submatches(text, next), "")}
+		messageStart = next[1]
+	}
+}
+
+// nextMarker finds the first marker at or after start that begins a new
+// task: one written in upper case, after punctuation or after a comment
+// opener. Prose like "fix bug: crash" stays in the message.
+func nextMarker(text string, start int, nextRe *regexp.Regexp) []int {
+	for _, next := range nextRe.FindAllStringSubmatchIndex(text[start:], -1) {
+		next = shiftIndexes(next, start)
+		marker := text[next[2]:next[3]]
+		if marker == strings.ToUpper(marker) || next[0] != next[2] {
+			return next
+		}
+		before := strings.TrimRight(text[start:next[0]], " \t")
+		if before != "" && strings.ContainsAny(before[len(before)-1:], ",;.!?") {
+			return next
+		}
+	}
+	return nil
+}
+
+// continuedTask is the last task of a line, the next comment line may
+// continue its message.
+type continuedTask struct {
+	index  int
+	kind   commentKind
+	opener string
+	// markerOffset is the byte offset of the marker in its line.
+	markerOffset int
+}
+
+// continuation returns the text continuing the task message, the comment
+// must be the same kind, start its line and be indented deeper than the
+// marker.
+func (t *continuedTask) continuation(line string, c comment) (string, bool) {
+	var text string
+	offset := c.offset
+	switch {
+	case t.kind == lineComment && c.kind == lineComment && c.opener == t.opener:
+		if strings.TrimSpace(line[:c.offset]) != "" {
+			return "", false
+		}
+		text = c.text[len(c.opener):]
+		offset += len(c.opener)
+	case (t.kind == blockCommentStart || t.kind == blockCommentLine) && c.kind == blockCommentLine:
+		text = strings.TrimSuffix(c.text, c.closer)
+		// Decorated block comments: " * text".
+		if trimmed := strings.TrimLeft(text, " \t"); strings.HasPrefix(trimmed, "*") {
+			offset += len(text) - len(trimmed) + 1
+			text = trimmed[1:]
+		}
+	case t.kind == docStringLine && c.kind == docStringLine:
+		text = c.text
+	default:
+		return "", false
+	}
+
+	trimmed := strings.TrimLeft(text, " \t")
+	if trimmed == "" || offset+len(text)-len(trimmed) <= t.markerOffset {
+		return "", false
+	}
+	return strings.TrimSpace(trimmed), true
+}
+
+func shiftIndexes(loc []int, offset int) []int {
+	shifted := make([]int, len(loc))
+	for i, index := range loc {
+		shifted[i] = index
+		if index >= 0 {
+			shifted[i] += offset
+		}
+	}
+	return shifted
+}
+
+func submatches(s string, loc []int) []string {
+	matches := make([]string, len(loc)/2)
+	for i := range matches {
+		if loc[2*i] >= 0 {
+			matches[i] = s[loc[2*i]:loc[2*i+1]]
+		}
+	}
+	return matches
+}
+
+type commentKind int
+
+const (
+	// lineComment runs from the opener to the end of the line.
+	lineComment commentKind = iota
+	// blockCommentStart runs from the opener to the end of the comment or the line.
+	blockCommentStart
+	// blockCommentLine is a line of a block comment started earlier, it keeps
+	// the closing delimiter.
+	blockCommentLine
+	// docStringLine is a line of docstring content without delimiters.
+	docStringLine
+)
+
+// comment is a part of a single line.
+type comment struct {
+	kind   commentKind
+	opener string
+	// closer is set when the block comment ends on the line.
+	closer string
+	// offset is the byte offset of text in the line.
+	offset int
+	text   string
+}
+
+type scanMode int
+
+const (
+	modeCode scanMode = iota
+	modeBlockComment
+	modeString
+	modeDocString
+)
+
+type delimiterKind int
+
+const (
+	lineCommentDelimiter delimiterKind = iota
+	blockCommentDelimiter
+	stringDelimiter
+	docStringDelimiter
+)
+
+type openingDelimiter struct {
+	text  string
+	kind  delimiterKind
+	index int
+	// end closes block comments and literals.
+	end string
+	// delimiter matches custom delimiters, e.g. of raw strings.
+	delimiter *regexp.Regexp
+	// prefix matches the text before the opening text.
+	prefix    *regexp.Regexp
+	lineStart bool
+}
+
+// compile sets the custom delimiter matched right after the opening text.
+func (o *openingDelimiter) compile(pattern string) error {
+	if pattern == "" {
+		return nil
+	}
+	re, err := regexp.Compile(`^(?:` + pattern + `)`)
+	if err != nil {
+		return err
+	}
+	o.delimiter = re
+	return nil
+}
+
+// commentScanner finds comments line by line, it keeps track of comments and
+// literals spanning several lines.
+type commentScanner struct {
+	lang Language
+	// openers are sorted longest first, so "--[[" wins over "--".
+	openers []openingDelimiter
+
+	mode  scanMode
+	index int
+	depth int
+	// end closes the current block comment or string, it differs from the
+	// defined end for custom delimiters.
+	end string
+	// heredoc starts with the next line, if any.
+	heredoc *pendingHeredoc
+}
+
+// pendingHeredoc is a heredoc literal opened on the current line.
+type pendingHeredoc struct {
+	index int
+	end   string
+}
+
+func newCommentScanner(lang Language) (*commentScanner, error) {
+	var openers []openingDelimiter
+	for i, opener := range lang.LineComments {
+		openers = append(openers, openingDelimiter{text: opener, kind: lineCommentDelimiter, index: i})
+	}
+	for i, block := range lang.BlockComments {
+		opener := openingDelimiter{text: block.Start, kind: blockCommentDelimiter, index: i, end: block.End, lineStart: block.LineStart}
+		if err := opener.compile(block.Delimiter); err != nil {
+			return nil, fmt.Errorf("invalid block comment delimiter: %w", err)
+		}
+		openers = append(openers, opener)
+	}
+	for _, literals := range []struct {
+		kind     delimiterKind
+		literals []StringLiteral
+	}{
+		{stringDelimiter, lang.Strings},
+		{docStringDelimiter, lang.DocStrings},
+	} {
+		for i, literal := range literals.literals {
+			opener := openingDelimiter{text: literal.Start, kind: literals.kind, index: i, end: literal.End}
+			if err := opener.compile(literal.Delimiter); err != nil {
+				return nil, fmt.Errorf("invalid string delimiter: %w", err)
+			}
+			if literal.Prefix != "" {
+				re, err := regexp.Compile(`(?:` + literal.Prefix + `)$`)
+				if err != nil {
+					return nil, fmt.Errorf("invalid string prefix: %w", err)
+				}
+				opener.prefix = re
+			}
+			openers = append(openers, opener)
+		}
+	}
+	openers = slices.DeleteFunc(openers, func(opener openingDelimiter) bool {
+		return opener.text == ""
+	})
+	slices.SortStableFunc(openers, func(a, b openingDelimiter) int {
+		return cmp.Compare(len(b.text), len(a.text))
+	})
+
+	return &commentScanner{lang: lang, openers: openers}, nil
+}
+
+// scanLine returns comments of the line in order.
+func (s *commentScanner) scanLine(line string) []comment {
+	if s.heredoc != nil && s.mode == modeCode {
+		s.mode, s.index, s.end = modeString, s.heredoc.index, s.heredoc.end
+	}
+	s.heredoc = nil
+
+	var comments []comment
+	pos := 0
+	for {
+		switch s.mode {
+		case modeBlockComment:
+			end, ok := s.blockCommentEnd(line, pos)
+			if !ok {
+				return append(comments, comment{kind: blockCommentLine, offset: pos, text: line[pos:]})
+			}
+			comments = append(comments, comment{kind: blockCommentLine, closer: s.end, offset: pos, text: line[pos:end]})
+			pos = end
+
+		case modeString:
+			literal := s.lang.Strings[s.index]
+			if literal.Heredoc {
+				if strings.TrimLeft(line, "\t") == s.end {
+					s.mode = modeCode
+				}
+				return comments
+			}
+			_, end, ok := stringEnd(line, pos, s.end, literal)
+			if !ok {
+				if !literal.Multiline {
+					s.mode = modeCode
+				}
+				return comments
+			}
+			pos = end
+			s.mode = modeCode
+
+		case modeDocString:
+			literal := s.lang.DocStrings[s.index]
+			contentEnd, end, ok := stringEnd(line, pos, s.end, literal)
+			comments = append(comments, comment{kind: docStringLine, offset: pos, text: line[pos:contentEnd]})
+			if !ok {
+				if !literal.Multiline {
+					s.mode = modeCode
+				}
+				return comments
+			}
+			pos = end
+			s.mode = modeCode
+
+		case modeCode:
+			start, end, opener, closing, ok := s.nextOpener(line, pos)
+			if !ok {
+				return comments
+			}
+			pos = end
+			s.index = opener.index
+
+			switch opener.kind {
+			case lineCommentDelimiter:
+				return append(comments, comment{kind: lineComment, opener: opener.text, offset: start, text: line[start:]})
+
+			case blockCommentDelimiter:
+				s.mode = modeBlockComment
+				s.depth = 1
+				s.end = closing
+				// The opener includes the custom delimiter, if any.
+				text := line[start:pos]
+				end, ok := s.blockCommentEnd(line, pos)
+				if !ok {
+					return append(comments, comment{kind: blockCommentStart, opener: text, offset: start, text: line[start:]})
+				}
+				comments = append(comments, comment{kind: blockCommentStart, opener: text, closer: s.end, offset: start, text: line[start:end]})
+				pos = end
+
+			case stringDelimiter:
+				if s.lang.Strings[s.index].Heredoc {
+					s.heredoc = &pendingHeredoc{index: s.index, end: closing}
+					continue
+				}
+				s.mode = modeString
+				s.end = closing
+
+			case docStringDelimiter:
+				s.mode = modeDocString
+				s.end = closing
+			}
+		}
+	}
+}
+
+// nextOpener finds the first comment or literal opening at or after pos, it
+// returns offsets of the opening start and end and the closing delimiter.
+func (s *commentScanner) nextOpener(line string, pos int) (int, int, openingDelimiter, string, bool) {
+	for i := pos; i < len(line); i++ {
+		if s.lang.Escape != "" && strings.HasPrefix(line[i:], s.lang.Escape) {
+			i += len(s.lang.Escape)
+			continue
+		}
+		for _, opener := range s.openers {
+			if !strings.HasPrefix(line[i:], opener.text) {
+				continue
+			}
+			if opener.kind == lineCommentDelimiter && s.lang.SpacedLineComments && i > 0 && line[i-1] != ' ' && line[i-1] != '\t' {
+				continue
+			}
+			if opener.lineStart && i > 0 || opener.prefix != nil && !opener.prefix.MatchString(line[:i]) {
+				continue
+			}
+			end := i + len(opener.text)
+			closing := opener.end
+			if opener.delimiter != nil {
+				loc := opener.delimiter.FindStringSubmatchIndex(line[end:])
+				if loc == nil {
+					continue
+				}
+				if len(loc) > 2 && loc[2] >= 0 {
+					closing = strings.ReplaceAll(closing, "%s", line[end+loc[2]:end+loc[3]])
+				}
+				end += loc[1]
+			}
+			return i, end, opener, closing, true
+		}
+	}
+	return 0, 0, openingDelimiter{}, "", false
+}
+
+// blockCommentEnd returns the offset after the end of the current block
+// comment, or the line length when the comment continues on the next line.
+func (s *commentScanner) blockCommentEnd(line string, pos int) (int, bool) {
+	block := s.lang.BlockComments[s.index]
+	if block.LineStart {
+		if pos == 0 && strings.HasPrefix(line, s.end) {
+			s.mode = modeCode
+			return len(s.end), true
+		}
+		return len(line), false
+	}
+	for i := pos; i < len(line); {
+		switch {
+		case s.lang.NestedComments && strings.HasPrefix(line[i:], block.Start):
+			s.depth++
+			i += len(block.Start)
+		case strings.HasPrefix(line[i:], s.end):
+			s.depth--
+			i += len(s.end)
+			if s.depth == 0 {
+				s.mode = modeCode
+				return i, true
+			}
+		default:
+			i++
+		}
+	}
+	return len(line), false
+}
+
+// stringEnd returns offsets of the closing delimiter and after it, or the
+// line length when the literal is not closed on the line.
+func stringEnd(line string, pos int, end string, literal StringLiteral) (int, int, bool) {
+	for i := pos; i < len(line); {
+		switch {
+		case literal.Escape != "" && strings.HasPrefix(line[i:], literal.Escape):
+			i += len(literal.Escape)
+			if i < len(line) {
+				_, size := utf8.DecodeRuneInString(line[i:])
+				i += size
+			}
+		case literal.DoubledEnd && end != "" && strings.HasPrefix(line[i:], end+end):
+			i += 2 * len(end)
+		case strings.HasPrefix(line[i:], end):
+			return i, i + len(end), true
+		default:
+			i++
+		}
+	}
+	return len(line), len(line), false
+}
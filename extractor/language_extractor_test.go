@@ -0,0 +1,81 @@
+package extractor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestCommentScanner(t *testing.T) {
+	lang := Language{
+		LineComments:   []string{"//"},
+		BlockComments:  []Delimiters{{Start: "/*", End: "*/"}},
+		NestedComments: true,
+		Strings:        []StringLiteral{{Start: `"`, End: `"`, Escape: `\`}},
+		DocStrings:     []StringLiteral{{Start: `"""`, End: `"""`, Multiline: true}},
+	}
+
+	tests := []struct {
+		name  string
+		lines []string
+		want  [][]comment
+	}{
+		{
+			name:  "line comment",
+			lines: []string{"x := 1 // a // b"},
+			want:  [][]comment{{{kind: lineComment, opener: "//", offset: 7, text: "// a // b"}}},
+		},
+		{
+			name:  "comment delimiters in string",
+			lines: []string{`s := "// \" /*" // a`},
+			want:  [][]comment{{{kind: lineComment, opener: "//", offset: 16, text: "// a"}}},
+		},
+		{
+			name:  "block comment on one line",
+			lines: []string{"/* a */ x /* b */"},
+			want: [][]comment{{
+				{kind: blockCommentStart, opener: "/*", closer: "*/", offset: 0, text: "/* a */"},
+				{kind: blockCommentStart, opener: "/*", closer: "*/", offset: 10, text: "/* b */"},
+			}},
+		},
+		{
+			name:  "nested block comment",
+			lines: []string{"/* a /* b */", "c */ // d"},
+			want: [][]comment{
+				{{kind: blockCommentStart, opener: "/*", offset: 0, text: "/* a /* b */"}},
+				{
+					{kind: blockCommentLine, closer: "*/", offset: 0, text: "c */"},
+					{kind: lineComment, opener: "//", offset: 5, text: "// d"},
+				},
+			},
+		},
+		{
+			name:  "docstring",
+			lines: []string{`"""a`, `b""" // c`},
+			want: [][]comment{
+				{{kind: docStringLine, offset: 3, text: "a"}},
+				{
+					{kind: docStringLine, offset: 0, text: "b"},
+					{kind: lineComment, opener: "//", offset: 5, text: "// c"},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scanner, err := newCommentScanner(lang)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var got [][]comment
+			for _, line := range tt.lines {
+				got = append(got, scanner.scanLine(line))
+			}
+
+			if diff := cmp.Diff(tt.want, got, cmp.AllowUnexported(comment{})); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package extractor
+
+// luaLanguage has long brackets of any level: --[==[ ]==] comments and
+// [==[ ]==] strings.
+var luaLanguage = Language{
+	LineComments:  []string{"--"},
+	BlockComments: []Delimiters{{Start: "--[", End: "]%s]", Delimiter: `(=*)\[`}},
+	Strings: []StringLiteral{
+		{Start: `"`, End: `"`, Escape: `\`},
+		{Start: `'`, End: `'`, Escape: `\`},
+		{Start: "[", End: "]%s]", Delimiter: `(=*)\[`, Multiline: true},
+	},
+}
+
+// NewLuaExtractor extracts tasks from -- and --[[ ]] comments, long brackets
+// --[==[ ]==] of any level and strings are respected.
+func NewLuaExtractor(filePath string, opts ...Option) Extractor {
+	return NewLanguageExtractor(luaLanguage, filePath, opts...)
+}
@@ -0,0 +1,62 @@
+package extractor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var checkboxRegex = regexp.MustCompile(`^- \[ \] (.+)`)
+
+// NewMarkdownExtractor extracts unchecked checkboxes: - [ ] task.
+func NewMarkdownExtractor(filePath string, opts ...Option) Extractor {
+	return ExtractorFunc(func(ctx context.Context) ([]Task, error) {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+
+		var tasks []Task
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(nil, 1024*1024) // Set max token size to 1MB for long lines
+		lineNum := 0
+
+		for scanner.Scan() {
+			if err := contextError(ctx, "extracting", filePath); err != nil {
+				return nil, err
+			}
+
+			lineNum++
+			if task, ok := checkboxTask(filePath, lineNum, scanner.Text()); ok {
+				tasks = append(tasks, task)
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading file: %w", err)
+		}
+
+		return tasks, nil
+	})
+}
+
+// checkboxTask returns the task of the line with an unchecked checkbox.
+func checkboxTask(filePath string, lineNum int, line string) (Task, bool) {
+	matches := checkboxRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return Task{}, false
+	}
+
+	return Task{
+		File:    filePath,
+		Line:    lineNum,
+		EndLine: lineNum,
+		Column:  strings.Index(line, "- [ ]") + 1,
+		Type:    "CHECKBOX",
+		Message: strings.TrimSpace(matches[1]),
+	}, true
+}
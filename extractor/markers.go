@@ -0,0 +1,66 @@
+package extractor
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// DefaultMarkers are recognised when no other markers are configured.
+var DefaultMarkers = []string{"TODO", "BUG", "NOTE"}
+
+var defaultMarkerSet = newMarkerSet(DefaultMarkers)
+
+// markerSet builds task regexes for the configured markers.
+//
+// Regexes are cached, so extractors sharing a set compile each pattern once.
+type markerSet struct {
+	core string
+	head string
+
+	mu      sync.Mutex
+	regexps map[string]*regexp.Regexp
+}
+
+func newMarkerSet(markers []string) *markerSet {
+	if len(markers) == 0 {
+		markers = DefaultMarkers
+	}
+	quoted := make([]string, 0, len(markers))
+	for _, marker := range markers {
+		quoted = append(quoted, regexp.QuoteMeta(marker))
+	}
+	head := `(?i)(` + strings.Join(quoted, "|") + `)(\([^)]*\))?:`
+	return &markerSet{
+		core:    head + `\s*(.+)`,
+		head:    head,
+		regexps: make(map[string]*regexp.Regexp),
+	}
+}
+
+// regexp compiles the pattern with %s replaced by the task regex core.
+//
+// The core has three groups: marker, optional assignee in parentheses, and message.
+func (m *markerSet) regexp(pattern string) *regexp.Regexp {
+	return m.compile(pattern, m.core)
+}
+
+// headRegexp compiles the pattern with %s replaced by the marker head, it is
+// the core without the message group.
+func (m *markerSet) headRegexp(pattern string) *regexp.Regexp {
+	return m.compile(pattern, m.head)
+}
+
+func (m *markerSet) compile(pattern string, sub string) *regexp.Regexp {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key := pattern + "\x00" + sub
+	if re, ok := m.regexps[key]; ok {
+		return re
+	}
+	re := regexp.MustCompile(fmt.Sprintf(pattern, sub))
+	m.regexps[key] = re
+	return re
+}
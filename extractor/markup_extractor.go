@@ -0,0 +1,144 @@
+package extractor
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+)
+
+var (
+	htmlLanguage = Language{
+		BlockComments: []Delimiters{{Start: "<!--", End: "-->"}},
+	}
+
+	cssLanguage = Language{
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		Strings:       cStrings,
+	}
+
+	// scssLanguage also covers Less and Stylus, they have // comments.
+	scssLanguage = Language{
+		LineComments:  []string{"//"},
+		BlockComments: []Delimiters{{Start: "/*", End: "*/"}},
+		Strings:       cStrings,
+	}
+)
+
+// NewHTMLExtractor extracts tasks from <!-- --> comments of HTML and XML.
+func NewHTMLExtractor(filePath string, opts ...Option) Extractor {
+	return NewLanguageExtractor(htmlLanguage, filePath, opts...)
+}
+
+var (
+	sectionStartRegex = regexp.MustCompile(`(?i)<(script|style)\b([^>]*)>`)
+	sectionEndRegexes = map[string]*regexp.Regexp{
+		"script": regexp.MustCompile(`(?i)</script\s*>`),
+		"style":  regexp.MustCompile(`(?i)</style\s*>`),
+	}
+	langAttributeRegex = regexp.MustCompile(`(?i)\blang\s*=\s*["']?(\w+)`)
+)
+
+// NewComponentExtractor extracts tasks from Vue and Svelte single-file
+// components: <!-- --> comments of the markup, JavaScript comments of
+// <script> and CSS comments of <style> sections.
+func NewComponentExtractor(filePath string, opts ...Option) Extractor {
+	o := newOptions(opts)
+
+	return ExtractorFunc(func(ctx context.Context) ([]Task, error) {
+		file, err := os.Open(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+		defer file.Close()
+
+		var tasks []Task
+		section, err := newTaskScanner(htmlLanguage, o.markers, filePath)
+		if err != nil {
+			return nil, err
+		}
+		// sectionEnd closes the current <script> or <style>, nil in markup.
+		var sectionEnd *regexp.Regexp
+
+		scanner := bufio.NewScanner(file)
+		scanner.Buffer(nil, 1024*1024) // Set max token size to 1MB for long lines
+		lineNum := 0
+
+		for scanner.Scan() {
+			if err := contextError(ctx, "extracting", filePath); err != nil {
+				return nil, err
+			}
+
+			lineNum++
+			line := scanner.Text()
+			// Sections may start and end in the middle of the line, every
+			// part goes to the scanner of its section.
+			for offset := 0; ; {
+				rest := line[offset:]
+				var loc []int
+				if sectionEnd == nil {
+					loc = sectionStartRegex.FindStringSubmatchIndex(rest)
+					if loc != nil && (section.comments.mode != modeCode || insideHTMLComment(rest[:loc[0]])) {
+						loc = nil
+					}
+				} else {
+					loc = sectionEnd.FindStringIndex(rest)
+				}
+				if loc == nil {
+					section.scanLine(lineNum, rest, offset)
+					break
+				}
+
+				lang := htmlLanguage
+				if sectionEnd == nil {
+					section.scanLine(lineNum, rest[:loc[1]], offset)
+					tag := strings.ToLower(rest[loc[2]:loc[3]])
+					lang = sectionLanguage(tag, rest[loc[4]:loc[5]])
+					sectionEnd = sectionEndRegexes[tag]
+					offset += loc[1]
+				} else {
+					section.scanLine(lineNum, rest[:loc[0]], offset)
+					sectionEnd = nil
+					offset += loc[0]
+				}
+
+				tasks = append(tasks, section.tasks...)
+				section, err = newTaskScanner(lang, o.markers, filePath)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		if err := scanner.Err(); err != nil {
+			return nil, fmt.Errorf("error reading file: %w", err)
+		}
+
+		return append(tasks, section.tasks...), nil
+	})
+}
+
+// sectionLanguage picks the language of <script> or <style> content by the
+// tag and its lang attribute.
+func sectionLanguage(tag string, attributes string) Language {
+	if tag == "script" {
+		lang := javaScriptLanguage
+		lang.KeepCloser = false
+		return lang
+	}
+	if matches := langAttributeRegex.FindStringSubmatch(attributes); matches != nil {
+		switch strings.ToLower(matches[1]) {
+		case "scss", "sass", "less", "stylus":
+			return scssLanguage
+		}
+	}
+	return cssLanguage
+}
+
+// insideHTMLComment reports whether the text ends inside a <!-- --> comment.
+func insideHTMLComment(text string) bool {
+	start := strings.LastIndex(text, "<!--")
+	return start >= 0 && !strings.Contains(text[start:], "-->")
+}
@@ -0,0 +1,301 @@
+package extractor
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// NewNotebookExtractor extracts tasks from Jupyter notebooks: Python comments
+// and docstrings of code cells and unchecked checkboxes of markdown cells.
+//
+// Line, EndLine and Column of the tasks point into the notebook JSON, Cell
+// and CellLine locate the task in the notebook.
+func NewNotebookExtractor(filePath string, opts ...Option) Extractor {
+	o := newOptions(opts)
+
+	return ExtractorFunc(func(ctx context.Context) ([]Task, error) {
+		data, err := os.ReadFile(filePath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open file: %w", err)
+		}
+
+		cells, err := parseNotebook(data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse notebook: %w", err)
+		}
+
+		var tasks []Task
+		for i, cell := range cells {
+			if err := contextError(ctx, "extracting", filePath); err != nil {
+				return nil, err
+			}
+
+			var cellTasks []Task
+			switch cell.kind {
+			case "code":
+				scanner, err := newTaskScanner(pythonLanguage, o.markers, filePath)
+				if err != nil {
+					return nil, err
+				}
+				for j, line := range cell.lines {
+					scanner.scanLine(j+1, line, 0)
+				}
+				cellTasks = scanner.tasks
+			case "markdown":
+				for j, line := range cell.lines {
+					if task, ok := checkboxTask(filePath, j+1, line); ok {
+						cellTasks = append(cellTasks, task)
+					}
+				}
+			}
+
+			for _, task := range cellTasks {
+				task.Cell = i + 1
+				task.CellLine = task.Line
+				task.Column = cell.rawColumns[task.Line-1][task.Column-1]
+				task.Line = cell.rawLines[task.Line-1]
+				task.EndLine = cell.rawLines[task.EndLine-1]
+				tasks = append(tasks, task)
+			}
+		}
+
+		return tasks, nil
+	})
+}
+
+// notebookCell is a cell of the notebook split into lines.
+type notebookCell struct {
+	kind  string
+	lines []string
+	// rawLines are lines of the notebook JSON the cell lines come from.
+	rawLines []int
+	// rawColumns are columns of the notebook JSON of every byte of the cell
+	// lines, escape sequences take several columns.
+	rawColumns [][]int
+}
+
+// notebookParser reads cells of nbformat 4 notebooks token by token to know
+// the line of every source string.
+type notebookParser struct {
+	data    []byte
+	decoder *json.Decoder
+	// offset is the byte offset the line is counted up to.
+	offset int
+	line   int
+}
+
+func parseNotebook(data []byte) ([]notebookCell, error) {
+	p := &notebookParser{data: data, decoder: json.NewDecoder(bytes.NewReader(data)), line: 1}
+
+	var cells []notebookCell
+	err := p.object(func(key string) error {
+		if key != "cells" {
+			return p.skip()
+		}
+		return p.array(func() error {
+			var cell notebookCell
+			err := p.object(func(key string) error {
+				switch key {
+				case "cell_type":
+					return p.decoder.Decode(&cell.kind)
+				case "source":
+					return p.source(&cell)
+				}
+				return p.skip()
+			})
+			cells = append(cells, cell)
+			return err
+		})
+	})
+	return cells, err
+}
+
+// source reads the cell source, a string or an array of lines.
+func (p *notebookParser) source(cell *notebookCell) error {
+	start := p.decoder.InputOffset()
+	token, err := p.decoder.Token()
+	if err != nil {
+		return err
+	}
+	if _, ok := token.(string); ok {
+		p.addLines(cell, start)
+		return nil
+	}
+	if token != json.Delim('[') {
+		return fmt.Errorf("unexpected %v in cell source", token)
+	}
+
+	for p.decoder.More() {
+		start := p.decoder.InputOffset()
+		token, err := p.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if _, ok := token.(string); !ok {
+			return fmt.Errorf("unexpected %v in cell source", token)
+		}
+		p.addLines(cell, start)
+	}
+	_, err = p.decoder.Token()
+	return err
+}
+
+// addLines appends lines of the string token just read, start is the offset
+// before the token and its separators.
+func (p *notebookParser) addLines(cell *notebookCell, start int64) {
+	end := int(p.decoder.InputOffset())
+	raw := p.data[int(start):end]
+	raw = raw[bytes.IndexByte(raw, '"'):]
+
+	// Strings have no raw line breaks, the token is on the line of its end.
+	p.line += bytes.Count(p.data[p.offset:end], []byte("\n"))
+	p.offset = end
+	lineStart := bytes.LastIndexByte(p.data[:end], '\n') + 1
+	column := end - len(raw) - lineStart + 1
+
+	lines, columns := sourceLines(raw, column)
+	for i, line := range lines {
+		cell.lines = append(cell.lines, line)
+		cell.rawLines = append(cell.rawLines, p.line)
+		cell.rawColumns = append(cell.rawColumns, columns[i])
+	}
+}
+
+// sourceLines decodes the raw JSON string starting at the column and splits
+// it into lines, columns hold the column of every byte of the lines. A
+// trailing line break does not start a new line.
+func sourceLines(raw []byte, column int) ([]string, [][]int) {
+	var lines []string
+	var columns [][]int
+	var line []byte
+	var lineColumns []int
+	newLine := func() {
+		if n := len(line); n > 0 && line[n-1] == '\r' {
+			line, lineColumns = line[:n-1], lineColumns[:n-1]
+		}
+		lines = append(lines, string(line))
+		columns = append(columns, lineColumns)
+		line, lineColumns = nil, nil
+	}
+
+	endsWithNewLine := false
+	for i := 1; i < len(raw)-1; {
+		endsWithNewLine = false
+		c := raw[i]
+		size := 1
+		if c == '\\' {
+			size = 2
+			switch raw[i+1] {
+			case 'n':
+				newLine()
+				endsWithNewLine = true
+				i += size
+				continue
+			case 'b':
+				c = '\b'
+			case 'f':
+				c = '\f'
+			case 'r':
+				c = '\r'
+			case 't':
+				c = '\t'
+			case 'u':
+				r, n := decodeUnicodeEscape(raw[i:])
+				for range utf8.RuneLen(r) {
+					lineColumns = append(lineColumns, column+i)
+				}
+				line = utf8.AppendRune(line, r)
+				i += n
+				continue
+			default:
+				c = raw[i+1]
+			}
+		}
+		line = append(line, c)
+		lineColumns = append(lineColumns, column+i)
+		i += size
+	}
+	if !endsWithNewLine || len(lines) == 0 {
+		newLine()
+	}
+	return lines, columns
+}
+
+// decodeUnicodeEscape decodes \uXXXX, or a surrogate pair of them, and
+// returns the rune and the length of the escape.
+func decodeUnicodeEscape(raw []byte) (rune, int) {
+	r := hexRune(raw[2:])
+	if utf16.IsSurrogate(r) && len(raw) >= 12 && raw[6] == '\\' && raw[7] == 'u' {
+		if pair := utf16.DecodeRune(r, hexRune(raw[8:])); pair != utf8.RuneError {
+			return pair, 12
+		}
+	}
+	if utf16.IsSurrogate(r) {
+		r = utf8.RuneError
+	}
+	return r, 6
+}
+
+func hexRune(raw []byte) rune {
+	if len(raw) < 4 {
+		return utf8.RuneError
+	}
+	r, err := strconv.ParseUint(string(raw[:4]), 16, 32)
+	if err != nil {
+		return utf8.RuneError
+	}
+	return rune(r)
+}
+
+// object reads an object calling field for every key, field must read the
+// value.
+func (p *notebookParser) object(field func(key string) error) error {
+	if err := p.delimiter('{'); err != nil {
+		return err
+	}
+	for p.decoder.More() {
+		token, err := p.decoder.Token()
+		if err != nil {
+			return err
+		}
+		if err := field(token.(string)); err != nil {
+			return err
+		}
+	}
+	return p.delimiter('}')
+}
+
+// array reads an array calling element for every element.
+func (p *notebookParser) array(element func() error) error {
+	if err := p.delimiter('['); err != nil {
+		return err
+	}
+	for p.decoder.More() {
+		if err := element(); err != nil {
+			return err
+		}
+	}
+	return p.delimiter(']')
+}
+
+func (p *notebookParser) delimiter(want json.Delim) error {
+	token, err := p.decoder.Token()
+	if err != nil {
+		return err
+	}
+	if token != want {
+		return fmt.Errorf("expected %v, got %v", want, token)
+	}
+	return nil
+}
+
+func (p *notebookParser) skip() error {
+	var value json.RawMessage
+	return p.decoder.Decode(&value)
+}
@@ -0,0 +1,59 @@
+package extractor
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSourceLines(t *testing.T) {
+	tests := []struct {
+		name        string
+		raw         string
+		wantLines   []string
+		wantColumns [][]int
+	}{
+		{
+			name:        "plain",
+			raw:         `"ab"`,
+			wantLines:   []string{"ab"},
+			wantColumns: [][]int{{2, 3}},
+		},
+		{
+			name:        "trailing line break",
+			raw:         `"a\n"`,
+			wantLines:   []string{"a"},
+			wantColumns: [][]int{{2}},
+		},
+		{
+			name:        "empty line",
+			raw:         `"\n"`,
+			wantLines:   []string{""},
+			wantColumns: [][]int{nil},
+		},
+		{
+			name:        "several lines",
+			raw:         `"a\r\nb"`,
+			wantLines:   []string{"a", "b"},
+			wantColumns: [][]int{{2}, {7}},
+		},
+		{
+			name:        "escapes",
+			raw:         `"\"\u00e9\ud83d\ude00x"`,
+			wantLines:   []string{"\"é😀x"},
+			wantColumns: [][]int{{2, 4, 4, 10, 10, 10, 10, 22}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lines, columns := sourceLines([]byte(tt.raw), 1)
+			if diff := cmp.Diff(tt.wantLines, lines); diff != "" {
+				t.Errorf("lines (-want +got):\n%s", diff)
+			}
+			if diff := cmp.Diff(tt.wantColumns, columns); diff != "" {
+				t.Errorf("columns (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+package extractor
+
+import (
+	"runtime"
+)
+
+// Option configures extractors created by the New*Extractor functions.
+type Option func(*options)
+
+type options struct {
+	markers   *markerSet
+	ignores   ignoreRules
+	gitignore bool
+	workers   int
+	// registry is nil for the default one, built-in extractors use
+	// options themselves.
+	registry *Registry
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		markers:   defaultMarkerSet,
+		gitignore: true,
+		workers:   runtime.GOMAXPROCS(0),
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// WithMarkers replaces [DefaultMarkers] with the given marker names.
+//
+// Markers are matched case insensitively and reported in upper case.
+// Empty list keeps the defaults.
+func WithMarkers(markers ...string) Option {
+	set := newMarkerSet(markers)
+	return func(o *options) {
+		o.markers = set
+	}
+}
+
+// WithIgnores skips the given absolute paths during directory traversal.
+func WithIgnores(paths ...string) Option {
+	rules := make(ignoreRules, 0, len(paths))
+	for _, path := range paths {
+		rules = append(rules, pathIgnoreRule(path))
+	}
+	return func(o *options) {
+		o.ignores = append(o.ignores, rules...)
+	}
+}
+
+// WithIgnorePatterns skips paths matching .mtignore patterns during
+// directory traversal. Patterns are relative to the base directory.
+func WithIgnorePatterns(base string, patterns ...string) Option {
+	var rules ignoreRules
+	for _, pattern := range patterns {
+		if rule, ok := parseIgnoreRule(base, pattern, true); ok {
+			rules = append(rules, rule)
+		}
+	}
+	return func(o *options) {
+		o.ignores = append(o.ignores, rules...)
+	}
+}
+
+// WithGitignore toggles git ignore rules during directory traversal, they
+// are enabled by default.
+//
+// Enabled rules are read from nested .gitignore files, the repository's
+// info/exclude and the global excludes file.
+func WithGitignore(enabled bool) Option {
+	return func(o *options) {
+		o.gitignore = enabled
+	}
+}
+
+// WithWorkers sets the number of files extracted concurrently during
+// directory traversal, non-positive n keeps the default of GOMAXPROCS.
+func WithWorkers(n int) Option {
+	return func(o *options) {
+		if n > 0 {
+			o.workers = n
+		}
+	}
+}
+
+// WithRegistry makes [NewFileExtractor] pick extractors from the registry
+// instead of [DefaultRegistry].
+func WithRegistry(registry *Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
@@ -0,0 +1,21 @@
+package extractor
+
+// pythonLanguage treats every triple-quoted string as a docstring. String
+// prefixes such as r, f and b need no special care: they precede the quotes
+// and a backslash skips the next character in raw strings as well.
+var pythonLanguage = Language{
+	LineComments: []string{"#"},
+	Strings: []StringLiteral{
+		{Start: `"`, End: `"`, Escape: `\`},
+		{Start: `'`, End: `'`, Escape: `\`},
+	},
+	DocStrings: []StringLiteral{
+		{Start: `"""`, End: `"""`, Escape: `\`, Multiline: true},
+		{Start: `'''`, End: `'''`, Escape: `\`, Multiline: true},
+	},
+}
+
+// NewPythonExtractor extracts tasks from # comments and docstrings.
+func NewPythonExtractor(filePath string, opts ...Option) Extractor {
+	return NewLanguageExtractor(pythonLanguage, filePath, opts...)
+}
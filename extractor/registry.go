@@ -0,0 +1,273 @@
+package extractor
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// Factory creates an extractor for a single file.
+type Factory func(filePath string, opts ...Option) Extractor
+
+// Registry maps files to extractor factories by exact filename, extension
+// and shebang interpreter, in this order of precedence.
+//
+// Registry must not be modified while it is used by extractors.
+type Registry struct {
+	names      map[string]Factory
+	extensions map[string]Factory
+	filenames  map[string]Factory
+	shebangs   map[string]Factory
+}
+
+// NewRegistry creates an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		names:      make(map[string]Factory),
+		extensions: make(map[string]Factory),
+		filenames:  make(map[string]Factory),
+		shebangs:   make(map[string]Factory),
+	}
+}
+
+// DefaultRegistry creates a registry with built-in extractors, it is safe to
+// modify the result.
+//
+// Built-in extractors are registered under names: ada, asciidoc, c, cmake,
+// elm, go, haskell, html, java, javascript, jupyter, lua, markdown, nix,
+// perl, powershell, python, r, ruby, rust, shell, sql, svelte, terraform,
+// toml, typst, vhdl, vue and yaml.
+func DefaultRegistry() *Registry {
+	r := NewRegistry()
+	goExtractor := LanguageFactory(goLanguage)
+	javaExtractor := LanguageFactory(javaLanguage)
+	javaScriptExtractor := LanguageFactory(javaScriptLanguage)
+	typstExtractor := LanguageFactory(typstLanguage)
+	rubyExtractor := LanguageFactory(rubyLanguage)
+	perlExtractor := LanguageFactory(perlLanguage)
+	yamlExtractor := LanguageFactory(yamlLanguage)
+	tomlExtractor := LanguageFactory(tomlLanguage)
+	terraformExtractor := LanguageFactory(terraformLanguage)
+	nixExtractor := LanguageFactory(nixLanguage)
+	rExtractor := LanguageFactory(rLanguage)
+	powerShellExtractor := LanguageFactory(powerShellLanguage)
+	cmakeExtractor := LanguageFactory(cmakeLanguage)
+	makeExtractor := LanguageFactory(makeLanguage)
+	dockerfileExtractor := LanguageFactory(dockerfileLanguage)
+	sqlExtractor := LanguageFactory(sqlLanguage)
+	haskellExtractor := LanguageFactory(haskellLanguage)
+	adaExtractor := LanguageFactory(adaLanguage)
+	vhdlExtractor := LanguageFactory(vhdlLanguage)
+
+	r.Register("asciidoc", NewAsciiDocExtractor)
+	r.Register("c", NewCCommentsExtractor)
+	r.Register("go", goExtractor)
+	r.Register("html", NewHTMLExtractor)
+	r.Register("java", javaExtractor)
+	r.Register("javascript", javaScriptExtractor)
+	r.Register("typst", typstExtractor)
+	r.Register("ruby", rubyExtractor)
+	r.Register("perl", perlExtractor)
+	r.Register("yaml", yamlExtractor)
+	r.Register("toml", tomlExtractor)
+	r.Register("terraform", terraformExtractor)
+	r.Register("nix", nixExtractor)
+	r.Register("r", rExtractor)
+	r.Register("powershell", powerShellExtractor)
+	r.Register("cmake", cmakeExtractor)
+	r.Register("sql", sqlExtractor)
+	r.Register("haskell", haskellExtractor)
+	r.Register("elm", haskellExtractor)
+	r.Register("ada", adaExtractor)
+	r.Register("vhdl", vhdlExtractor)
+	r.Register("lua", NewLuaExtractor)
+	r.Register("markdown", NewMarkdownExtractor)
+	r.Register("jupyter", NewNotebookExtractor)
+	r.Register("python", NewPythonExtractor)
+	r.Register("rust", NewRustExtractor)
+	r.Register("shell", NewShellExtractor)
+	r.Register("svelte", NewComponentExtractor)
+	r.Register("vue", NewComponentExtractor)
+
+	r.RegisterExtension(".md", NewMarkdownExtractor)
+	r.RegisterExtension(".ipynb", NewNotebookExtractor)
+	r.RegisterExtension(".lua", NewLuaExtractor)
+	for _, ext := range []string{".sh", ".bash"} {
+		r.RegisterExtension(ext, NewShellExtractor)
+	}
+	r.RegisterExtension(".py", NewPythonExtractor)
+	r.RegisterExtension(".rs", NewRustExtractor)
+	r.RegisterExtension(".adoc", NewAsciiDocExtractor)
+	for _, ext := range []string{".c", ".h", ".cpp", ".hpp", ".cxx", ".cc"} {
+		r.RegisterExtension(ext, NewCCommentsExtractor)
+	}
+	r.RegisterExtension(".go", goExtractor)
+	r.RegisterExtension(".java", javaExtractor)
+	for _, ext := range []string{".js", ".mjs", ".ts", ".mts"} {
+		r.RegisterExtension(ext, javaScriptExtractor)
+	}
+	r.RegisterExtension(".typ", typstExtractor)
+	r.RegisterExtension(".rb", rubyExtractor)
+	for _, ext := range []string{".pl", ".pm"} {
+		r.RegisterExtension(ext, perlExtractor)
+	}
+	for _, ext := range []string{".yaml", ".yml"} {
+		r.RegisterExtension(ext, yamlExtractor)
+	}
+	r.RegisterExtension(".toml", tomlExtractor)
+	for _, ext := range []string{".tf", ".tfvars"} {
+		r.RegisterExtension(ext, terraformExtractor)
+	}
+	r.RegisterExtension(".nix", nixExtractor)
+	r.RegisterExtension(".r", rExtractor)
+	for _, ext := range []string{".ps1", ".psm1"} {
+		r.RegisterExtension(ext, powerShellExtractor)
+	}
+	r.RegisterExtension(".cmake", cmakeExtractor)
+	r.RegisterExtension(".sql", sqlExtractor)
+	for _, ext := range []string{".hs", ".elm"} {
+		r.RegisterExtension(ext, haskellExtractor)
+	}
+	for _, ext := range []string{".ada", ".adb", ".ads"} {
+		r.RegisterExtension(ext, adaExtractor)
+	}
+	for _, ext := range []string{".vhd", ".vhdl"} {
+		r.RegisterExtension(ext, vhdlExtractor)
+	}
+	for _, ext := range []string{".html", ".htm", ".xhtml", ".xml", ".xsd", ".xsl", ".svg"} {
+		r.RegisterExtension(ext, NewHTMLExtractor)
+	}
+	for _, ext := range []string{".vue", ".svelte"} {
+		r.RegisterExtension(ext, NewComponentExtractor)
+	}
+
+	for _, name := range []string{"Makefile", "makefile", "GNUmakefile"} {
+		r.RegisterFilename(name, makeExtractor)
+	}
+	for _, name := range []string{"Dockerfile", "Containerfile"} {
+		r.RegisterFilename(name, dockerfileExtractor)
+	}
+	r.RegisterFilename("Jenkinsfile", javaExtractor)
+	for _, name := range []string{"Gemfile", "Rakefile"} {
+		r.RegisterFilename(name, rubyExtractor)
+	}
+	r.RegisterFilename("CMakeLists.txt", cmakeExtractor)
+
+	for _, interpreter := range []string{"sh", "bash", "zsh", "ksh", "dash"} {
+		r.RegisterShebang(interpreter, NewShellExtractor)
+	}
+	r.RegisterShebang("python", NewPythonExtractor)
+	r.RegisterShebang("lua", NewLuaExtractor)
+	r.RegisterShebang("ruby", rubyExtractor)
+	r.RegisterShebang("perl", perlExtractor)
+	r.RegisterShebang("pwsh", powerShellExtractor)
+	r.RegisterShebang("node", javaScriptExtractor)
+
+	return r
+}
+
+var defaultRegistry = DefaultRegistry()
+
+// Register makes the factory available by name, e.g. for configuration files.
+func (r *Registry) Register(name string, factory Factory) {
+	r.names[name] = factory
+}
+
+// Factory returns the factory registered by name.
+func (r *Registry) Factory(name string) (Factory, bool) {
+	factory, ok := r.names[name]
+	return factory, ok
+}
+
+// RegisterExtension maps files with the extension (".tsx") to the factory.
+// Extensions are case insensitive.
+func (r *Registry) RegisterExtension(ext string, factory Factory) {
+	r.extensions[strings.ToLower(ext)] = factory
+}
+
+// RegisterFilename maps files with exactly this name ("Dockerfile") to the factory.
+func (r *Registry) RegisterFilename(name string, factory Factory) {
+	r.filenames[name] = factory
+}
+
+// RegisterShebang maps scripts run by the interpreter ("python") to the
+// factory. Versions are ignored on lookup: python3.12 matches python.
+func (r *Registry) RegisterShebang(interpreter string, factory Factory) {
+	r.shebangs[interpreter] = factory
+}
+
+// Lookup finds the factory for the file. The first line of the file is
+// read only when neither the filename nor the extension is registered.
+func (r *Registry) Lookup(filePath string) (Factory, bool, error) {
+	if factory, ok := r.filenames[filepath.Base(filePath)]; ok {
+		return factory, true, nil
+	}
+	if factory, ok := r.extensions[strings.ToLower(filepath.Ext(filePath))]; ok {
+		return factory, true, nil
+	}
+	if len(r.shebangs) == 0 {
+		return nil, false, nil
+	}
+
+	interpreter, err := readShebangInterpreter(filePath)
+	if err != nil {
+		return nil, false, err
+	}
+	if interpreter == "" {
+		return nil, false, nil
+	}
+	if factory, ok := r.shebangs[interpreter]; ok {
+		return factory, true, nil
+	}
+	if factory, ok := r.shebangs[strings.TrimRight(interpreter, "0123456789.")]; ok {
+		return factory, true, nil
+	}
+	return nil, false, nil
+}
+
+// readShebangInterpreter returns the interpreter name from the shebang line:
+// "#!/bin/bash" and "#!/usr/bin/env -S bash -e" both result in "bash".
+func readShebangInterpreter(filePath string) (string, error) {
+	// Opening a FIFO or a device blocks, only regular files are read.
+	info, err := os.Stat(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	if !info.Mode().IsRegular() {
+		return "", nil
+	}
+
+	file, err := os.Open(filePath)
+	if err != nil {
+		return "", fmt.Errorf("failed to open file: %w", err)
+	}
+	defer file.Close()
+
+	// Shebang lines are short, binary files don't have to be read whole.
+	line, err := bufio.NewReaderSize(file, 256).ReadSlice('\n')
+	if err != nil && len(line) == 0 {
+		return "", nil
+	}
+	rest, ok := strings.CutPrefix(string(line), "#!")
+	if !ok {
+		return "", nil
+	}
+
+	fields := strings.Fields(rest)
+	if len(fields) == 0 {
+		return "", nil
+	}
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") && !strings.Contains(field, "=") {
+				interpreter = filepath.Base(field)
+				break
+			}
+		}
+	}
+	return interpreter, nil
+}
@@ -0,0 +1,41 @@
+package extractor
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestReadShebangInterpreter(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "absolute path", content: "#!/bin/bash\necho\n", want: "bash"},
+		{name: "env", content: "#!/usr/bin/env python3\n", want: "python3"},
+		{name: "env with flags", content: "#!/usr/bin/env -S bash -e\n", want: "bash"},
+		{name: "env with variables", content: "#!/usr/bin/env LANG=C sh\n", want: "sh"},
+		{name: "no trailing newline", content: "#! /bin/sh", want: "sh"},
+		{name: "no shebang", content: "# TODO: task\n", want: ""},
+		{name: "empty shebang", content: "#!\n", want: ""},
+		{name: "empty file", content: "", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "script")
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := readShebangInterpreter(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
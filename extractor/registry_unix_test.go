@@ -0,0 +1,43 @@
+//go:build unix
+
+package extractor
+
+import (
+	"os"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+)
+
+func TestDirectoryExtractorSkipsFIFO(t *testing.T) {
+	dir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(dir, "a.go"), []byte("// TODO: task\n"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if err := syscall.Mkfifo(filepath.Join(dir, "pipe"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	type result struct {
+		tasks []Task
+		err   error
+	}
+	done := make(chan result, 1)
+	go func() {
+		tasks, err := NewDirectoryExtractor(dir).Extract(t.Context())
+		done <- result{tasks, err}
+	}()
+
+	select {
+	case got := <-done:
+		if got.err != nil {
+			t.Fatalf("unexpected error: %v", got.err)
+		}
+		if len(got.tasks) != 1 {
+			t.Errorf("want 1 task, got %v", got.tasks)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("extraction blocked on the FIFO")
+	}
+}
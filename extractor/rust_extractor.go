@@ -0,0 +1,20 @@
+package extractor
+
+var rustLanguage = Language{
+	LineComments:   []string{"//", "///", "//!"},
+	BlockComments:  []Delimiters{{Start: "/*", End: "*/"}},
+	NestedComments: true,
+	Strings: []StringLiteral{
+		{Start: `"`, End: `"`, Escape: `\`, Multiline: true},
+		// Raw strings: r"...", r#"..."#, br##"..."##.
+		{Start: "r", End: `"%s`, Delimiter: `(#*)"`, Multiline: true},
+		// Character literals, lifetimes like 'a are not literals.
+		{Start: "'", Delimiter: `(?:\\(?:u\{[0-9a-fA-F]*\}|x[0-9a-fA-F]{2}|.)|[^\\'])'`},
+	},
+}
+
+// NewRustExtractor extracts tasks from //, ///, //! and nested /* */
+// comments, comment delimiters in literals are ignored.
+func NewRustExtractor(filePath string, opts ...Option) Extractor {
+	return NewLanguageExtractor(rustLanguage, filePath, opts...)
+}
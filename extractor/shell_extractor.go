@@ -0,0 +1,49 @@
+package extractor
+
+var (
+	// shellLanguage starts comments only at words, so $# and ${#array[@]}
+	// are parameter expansions.
+	shellLanguage = Language{
+		LineComments:       []string{"#"},
+		SpacedLineComments: true,
+		Escape:             `\`,
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`, Multiline: true},
+			{Start: `'`, End: `'`, Multiline: true},
+			// ANSI-C quoting.
+			{Start: `$'`, End: `'`, Escape: `\`, Multiline: true},
+			// Arithmetic $(( )) and (( )), << is a shift there.
+			{Start: "((", End: "))", Multiline: true},
+			// <<EOF, <<-EOF, <<'EOF' and <<"EOF", but not <<< here-strings.
+			{Start: "<<", End: "%s", Delimiter: `-?[ \t]*['"]?([A-Za-z_]\w*)['"]?`, Prefix: `^|[^<]`, Heredoc: true},
+		},
+	}
+
+	// makeLanguage skips quotes only in recipe lines, which start with a tab
+	// and are run by the shell: VERSION = it's here has no quoting.
+	makeLanguage = Language{
+		LineComments: []string{"#"},
+		Escape:       `\`,
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`, Prefix: `^\t.*`},
+			{Start: `'`, End: `'`, Prefix: `^\t.*`},
+		},
+	}
+
+	// dockerfileLanguage covers Dockerfiles: instructions are not shell
+	// scripts as a whole, so quotes never span lines.
+	dockerfileLanguage = Language{
+		LineComments: []string{"#"},
+		Escape:       `\`,
+		Strings: []StringLiteral{
+			{Start: `"`, End: `"`, Escape: `\`},
+			{Start: `'`, End: `'`},
+		},
+	}
+)
+
+// NewShellExtractor extracts tasks from # comments, quoted strings and
+// heredoc bodies are skipped.
+func NewShellExtractor(filePath string, opts ...Option) Extractor {
+	return NewLanguageExtractor(shellLanguage, filePath, opts...)
+}
@@ -0,0 +1,83 @@
+package extractor
+
+import (
+	"context"
+	"errors"
+	"iter"
+)
+
+// StreamExtractor extracts tasks incrementally, so callers can handle them
+// before the whole extraction is done.
+//
+// The stream yields either a task or an error. A [*ScanError] is not fatal:
+// the stream goes on with other paths. Any other error is the last element
+// of the stream.
+type StreamExtractor interface {
+	Extractor
+	Stream(ctx context.Context) iter.Seq2[Task, error]
+}
+
+// StreamFunc adapts a function to [StreamExtractor].
+type StreamFunc func(ctx context.Context) iter.Seq2[Task, error]
+
+func (f StreamFunc) Stream(ctx context.Context) iter.Seq2[Task, error] {
+	return f(ctx)
+}
+
+// Extract collects the whole stream, see [Collect].
+func (f StreamFunc) Extract(ctx context.Context) ([]Task, error) {
+	return Collect(f(ctx))
+}
+
+// Collect gathers the stream into a slice.
+//
+// It returns nil tasks and the error on a fatal error. Non-fatal errors are
+// returned as [ScanErrors] along with the tasks.
+func Collect(stream iter.Seq2[Task, error]) ([]Task, error) {
+	var tasks []Task
+	var scanErrs ScanErrors
+	for task, err := range stream {
+		if err != nil {
+			var scanErr *ScanError
+			if errors.As(err, &scanErr) {
+				scanErrs = append(scanErrs, scanErr)
+				continue
+			}
+			return nil, err
+		}
+		tasks = append(tasks, task)
+	}
+
+	if len(scanErrs) > 0 {
+		return tasks, scanErrs
+	}
+	return tasks, nil
+}
+
+// Stream streams tasks of the extractor. Extractors which don't implement
+// [StreamExtractor] are extracted at once and their tasks are yielded after.
+func Stream(ctx context.Context, extractor Extractor) iter.Seq2[Task, error] {
+	if streamExtractor, ok := extractor.(StreamExtractor); ok {
+		return streamExtractor.Stream(ctx)
+	}
+
+	return func(yield func(Task, error) bool) {
+		tasks, err := extractor.Extract(ctx)
+		for _, task := range tasks {
+			if !yield(task, nil) {
+				return
+			}
+		}
+
+		var scanErrs ScanErrors
+		if errors.As(err, &scanErrs) {
+			for _, scanErr := range scanErrs {
+				if !yield(Task{}, scanErr) {
+					return
+				}
+			}
+		} else if err != nil {
+			yield(Task{}, err)
+		}
+	}
+}
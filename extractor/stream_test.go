@@ -0,0 +1,58 @@
+package extractor
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDirectoryExtractorStream(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a.go":          "// TODO: a\n// BUG: a\n",
+		"b/.mtignore/x": "",
+		"c/d.md":        "- [ ] d\n",
+		"e.go":          "// NOTE: e\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var got []string
+	for task, err := range NewDirectoryExtractor(dir, WithWorkers(4)).Stream(t.Context()) {
+		if err != nil {
+			var scanErr *ScanError
+			if !errors.As(err, &scanErr) {
+				t.Fatalf("unexpected fatal error: %v", err)
+			}
+			got = append(got, "error: "+scanErr.Op+" "+scanErr.Path)
+			continue
+		}
+		got = append(got, task.Type+": "+task.Message)
+	}
+
+	want := []string{
+		"TODO: a",
+		"BUG: a",
+		"error: read directory " + filepath.Join(dir, "b"),
+		"CHECKBOX: d",
+		"NOTE: e",
+	}
+	if diff := cmp.Diff(want, got); diff != "" {
+		t.Errorf("(-want +got):\n%s", diff)
+	}
+
+	// Stopping early must not block the extractor.
+	for range NewDirectoryExtractor(dir, WithWorkers(1)).Stream(t.Context()) {
+		break
+	}
+}
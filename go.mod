@@ -3,6 +3,8 @@ module github.com/IlyasYOY/monotask
 go 1.25.6
 
 require (
+	github.com/BurntSushi/toml v1.6.0
 	github.com/IlyasYOY/exectest v0.1.1
 	github.com/google/go-cmp v0.7.0
+	gopkg.in/yaml.v3 v3.0.1
 )
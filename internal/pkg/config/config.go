@@ -0,0 +1,205 @@
+// Package config loads monotask project configuration files.
+//
+// A project configuration is a .monotask.toml or .monotask.yaml (.monotask.yml)
+// file, it is looked up from the scanned directory upward, so the whole
+// project shares the same settings.
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+
+	"github.com/BurntSushi/toml"
+	"gopkg.in/yaml.v3"
+)
+
+// Filenames are project configuration file names in order of precedence.
+var Filenames = []string{".monotask.toml", ".monotask.yaml", ".monotask.yml"}
+
+// Config is a project configuration.
+//
+// Zero values mean "not set", so command line flags and defaults apply.
+type Config struct {
+	// Path is the absolute path to the loaded file.
+	Path string `toml:"-" yaml:"-"`
+
+	// Markers replace the default TODO, BUG and NOTE markers.
+	Markers []string `toml:"markers" yaml:"markers"`
+	// Format is the output format name.
+	Format string `toml:"format" yaml:"format"`
+	// Ignore lists .mtignore patterns, relative to the configuration file directory.
+	Ignore []string `toml:"ignore" yaml:"ignore"`
+	// Gitignore toggles .gitignore support, nil means enabled.
+	Gitignore *bool `toml:"gitignore" yaml:"gitignore"`
+	// Workers is the number of files extracted concurrently.
+	Workers int `toml:"workers" yaml:"workers"`
+	// Strict fails the run when any path could not be scanned.
+	Strict *bool `toml:"strict" yaml:"strict"`
+	// Extensions maps file extensions (".tsx") to extractor names ("c").
+	Extensions map[string]string `toml:"extensions" yaml:"extensions"`
+	// Filenames maps exact file names ("Justfile") to extractor names ("shell").
+	Filenames map[string]string `toml:"filenames" yaml:"filenames"`
+	// Shebangs maps script interpreters ("deno") to extractor names ("c").
+	Shebangs map[string]string `toml:"shebangs" yaml:"shebangs"`
+	// Languages declare comment syntax by extractor name, the names can be
+	// used in Extensions, Filenames and Shebangs as well.
+	Languages map[string]Language `toml:"languages" yaml:"languages"`
+	// Policy fails the run when extracted tasks violate it.
+	Policy Policy `toml:"policy" yaml:"policy"`
+}
+
+// Language is a declarative language definition.
+type Language struct {
+	// Extensions are file extensions of the language, e.g. ".sql".
+	Extensions []string `toml:"extensions" yaml:"extensions"`
+	// Filenames are exact file names of the language, e.g. "Tiltfile".
+	Filenames []string `toml:"filenames" yaml:"filenames"`
+	// LineComments start comments running to the end of the line, e.g. "--".
+	LineComments []string `toml:"line_comments" yaml:"line_comments"`
+	// SpacedLineComments start only at the line start or after whitespace.
+	SpacedLineComments bool `toml:"spaced_line_comments" yaml:"spaced_line_comments"`
+	// BlockComments are comments between delimiters, e.g. /* and */.
+	BlockComments []Delimiters `toml:"block_comments" yaml:"block_comments"`
+	// NestedComments allows block comments inside block comments.
+	NestedComments bool `toml:"nested_comments" yaml:"nested_comments"`
+	// Strings are literals where comment delimiters are ignored.
+	Strings []StringLiteral `toml:"strings" yaml:"strings"`
+}
+
+// Delimiters are start and end of a block comment.
+type Delimiters struct {
+	Start string `toml:"start" yaml:"start"`
+	End   string `toml:"end" yaml:"end"`
+}
+
+// StringLiteral describes a kind of string literal.
+type StringLiteral struct {
+	Start string `toml:"start" yaml:"start"`
+	// End is the same as Start when empty.
+	End string `toml:"end" yaml:"end"`
+	// Escape makes the next character a part of the literal, e.g. "\\".
+	Escape string `toml:"escape" yaml:"escape"`
+	// DoubledEnd makes doubled End a part of the literal: 'it''s'.
+	DoubledEnd bool `toml:"doubled_end" yaml:"doubled_end"`
+	// Multiline literals continue on the next line when not closed.
+	Multiline bool `toml:"multiline" yaml:"multiline"`
+	// Delimiter is a regular expression matched after Start for raw strings,
+	// its first group replaces %s in End.
+	Delimiter string `toml:"delimiter" yaml:"delimiter"`
+}
+
+// Policy is a set of rules checked against extracted tasks.
+type Policy struct {
+	// FailOn lists task types which must not be present, e.g. BUG.
+	FailOn []string `toml:"fail_on" yaml:"fail_on"`
+	// MaxTasks is the maximum allowed number of tasks, nil means no limit.
+	MaxTasks *int `toml:"max_tasks" yaml:"max_tasks"`
+}
+
+// Find looks for a configuration file in dir and its parents.
+//
+// It returns an empty path when there is no configuration file.
+func Find(dir string) (string, error) {
+	dir, err := filepath.Abs(dir)
+	if err != nil {
+		return "", err
+	}
+
+	for {
+		for _, name := range Filenames {
+			path := filepath.Join(dir, name)
+			info, err := os.Stat(path)
+			if err == nil && !info.IsDir() {
+				return path, nil
+			}
+			if err != nil && !errors.Is(err, os.ErrNotExist) {
+				return "", err
+			}
+		}
+
+		parent := filepath.Dir(dir)
+		if parent == dir {
+			return "", nil
+		}
+		dir = parent
+	}
+}
+
+// Load reads the configuration file, the format is chosen by the extension.
+//
+// Unknown keys are reported as errors to catch typos early.
+func Load(path string) (*Config, error) {
+	path, err := filepath.Abs(path)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{Path: path}
+	switch filepath.Ext(path) {
+	case ".toml":
+		meta, err := toml.Decode(string(data), config)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		if undecoded := meta.Undecoded(); len(undecoded) > 0 {
+			return nil, fmt.Errorf("%s: unknown key %q", path, undecoded[0].String())
+		}
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		// Empty file is a valid empty configuration.
+		if err := decoder.Decode(config); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("%s: unsupported configuration format", path)
+	}
+
+	for _, name := range slices.Sorted(maps.Keys(config.Languages)) {
+		if err := config.Languages[name].validate(); err != nil {
+			return nil, fmt.Errorf("%s: language %q: %w", path, name, err)
+		}
+	}
+
+	return config, nil
+}
+
+func (l Language) validate() error {
+	if len(l.LineComments) == 0 && len(l.BlockComments) == 0 {
+		return errors.New("no line or block comments")
+	}
+	if slices.Contains(l.LineComments, "") {
+		return errors.New("empty line comment")
+	}
+	for _, block := range l.BlockComments {
+		if block.Start == "" || block.End == "" {
+			return errors.New("block comment needs start and end")
+		}
+	}
+	for _, literal := range l.Strings {
+		if literal.Start == "" {
+			return errors.New("string needs start")
+		}
+		if _, err := regexp.Compile(literal.Delimiter); err != nil {
+			return fmt.Errorf("string delimiter: %w", err)
+		}
+	}
+	return nil
+}
+
+// Dir is the directory containing the configuration file.
+func (c *Config) Dir() string {
+	return filepath.Dir(c.Path)
+}
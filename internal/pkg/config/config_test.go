@@ -0,0 +1,124 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/IlyasYOY/monotask/internal/pkg/config"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestFind(t *testing.T) {
+	root := t.TempDir()
+	nested := filepath.Join(root, "a", "b")
+	if err := os.MkdirAll(nested, 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err := config.Find(nested)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "" {
+		t.Fatalf("expected no config, got %q", path)
+	}
+
+	want := filepath.Join(root, "a", ".monotask.yaml")
+	if err := os.WriteFile(want, nil, 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	path, err = config.Find(nested)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != want {
+		t.Errorf("want %q, got %q", want, path)
+	}
+}
+
+func TestLoad(t *testing.T) {
+	maxTasks := 3
+	want := &config.Config{
+		Markers:    []string{"TODO", "FIXME"},
+		Format:     "json",
+		Ignore:     []string{"build"},
+		Extensions: map[string]string{".tsx": "c"},
+		Languages: map[string]config.Language{
+			"sql": {
+				Extensions:    []string{".sql"},
+				LineComments:  []string{"--"},
+				BlockComments: []config.Delimiters{{Start: "/*", End: "*/"}},
+				Strings:       []config.StringLiteral{{Start: "'"}},
+			},
+		},
+		Policy: config.Policy{
+			FailOn:   []string{"BUG"},
+			MaxTasks: &maxTasks,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: ".monotask.toml",
+			content: `markers = ["TODO", "FIXME"]
+format = "json"
+ignore = ["build"]
+
+[extensions]
+".tsx" = "c"
+
+[languages.sql]
+extensions = [".sql"]
+line_comments = ["--"]
+block_comments = [{ start = "/*", end = "*/" }]
+strings = [{ start = "'" }]
+
+[policy]
+fail_on = ["BUG"]
+max_tasks = 3
+`,
+		},
+		{
+			name: ".monotask.yaml",
+			content: `markers: [TODO, FIXME]
+format: json
+ignore: [build]
+extensions:
+  .tsx: c
+languages:
+  sql:
+    extensions: [.sql]
+    line_comments: ["--"]
+    block_comments: [{start: /*, end: "*/"}]
+    strings: [{start: "'"}]
+policy:
+  fail_on: [BUG]
+  max_tasks: 3
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), tt.name)
+			if err := os.WriteFile(path, []byte(tt.content), 0o644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := config.Load(path)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			want.Path = path
+			if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("(-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -1,115 +0,0 @@
-package extractor
-
-import (
-	"bufio"
-	"context"
-	"fmt"
-	"os"
-	"regexp"
-	"strings"
-)
-
-var (
-	singleLineRegex  = regexp.MustCompile(`--\s*` + taskRegexCore)
-	inBlockLineRegex = regexp.MustCompile(`\s*` + taskRegexCore)
-)
-
-func NewLuaExtractor(filePath string) Extractor {
-	return ExtractorFunc(func(ctx context.Context) ([]Task, error) {
-		file, err := os.Open(filePath)
-		if err != nil {
-			return nil, fmt.Errorf("failed to open file: %w", err)
-		}
-		defer file.Close()
-
-		var tasks []Task
-		scanner := bufio.NewScanner(file)
-		scanner.Buffer(nil, 1024*1024) // Set max token size to 1MB for long lines
-		lineNum := 0
-		inBlockComment := false
-
-		for scanner.Scan() {
-			lineNum++
-			line := scanner.Text()
-
-			if inBlockComment {
-				if before, after, ok := strings.Cut(line, "]]"); ok {
-					inBlockComment = false
-
-					// Check content before ]] for tasks
-					beforeEnd := before
-					if matches := inBlockLineRegex.FindStringSubmatch(beforeEnd); len(matches) > 0 {
-						col := strings.Index(line, matches[0]) + 1
-						task := ParseTask(matches, filePath, lineNum, col)
-						tasks = append(tasks, task)
-					}
-
-					// Check after ]] for single-line comments
-					afterEnd := strings.TrimSpace(after)
-					if strings.HasPrefix(afterEnd, "--") {
-						if matches := singleLineRegex.FindStringSubmatch(afterEnd); len(matches) > 0 {
-							col := strings.Index(line, afterEnd) + 1
-							task := ParseTask(matches, filePath, lineNum, col)
-							tasks = append(tasks, task)
-						}
-					}
-				} else {
-					// Still inside block comment, check this line for tasks
-					if matches := inBlockLineRegex.FindStringSubmatch(line); len(matches) > 0 {
-						col := strings.Index(line, matches[0]) + 1
-						task := ParseTask(matches, filePath, lineNum, col)
-						tasks = append(tasks, task)
-					}
-				}
-				continue
-			}
-
-			// Check for start of block comment
-			if _, after, ok := strings.Cut(line, "--[["); ok {
-				inBlockComment = true
-
-				// Check content after --[[ for tasks
-				if before, after0, ok0 := strings.Cut(after, "]]"); ok0 {
-					// Block comment ends on same line
-					blockContent := before
-					if matches := inBlockLineRegex.FindStringSubmatch(blockContent); len(matches) > 0 {
-						col := strings.Index(line, matches[0]) + strings.Index(after, matches[0]) + 1
-						task := ParseTask(matches, filePath, lineNum, col)
-						tasks = append(tasks, task)
-					}
-					inBlockComment = false
-					// Check after ]] for single-line comments
-					afterEnd := strings.TrimSpace(after0)
-					if strings.HasPrefix(afterEnd, "--") {
-						if matches := singleLineRegex.FindStringSubmatch(afterEnd); len(matches) > 0 {
-							col := strings.Index(line, afterEnd) + 1
-							task := ParseTask(matches, filePath, lineNum, col)
-							tasks = append(tasks, task)
-						}
-					}
-				} else {
-					// Block comment continues to next line
-					if matches := inBlockLineRegex.FindStringSubmatch(after); len(matches) > 0 {
-						col := strings.Index(line, matches[0]) + 1
-						task := ParseTask(matches, filePath, lineNum, col)
-						tasks = append(tasks, task)
-					}
-				}
-				continue
-			}
-
-			// Check for single-line comments
-			if matches := singleLineRegex.FindStringSubmatch(line); len(matches) > 0 {
-				col := strings.Index(line, matches[0]) + 1
-				task := ParseTask(matches, filePath, lineNum, col)
-				tasks = append(tasks, task)
-			}
-		}
-
-		if err := scanner.Err(); err != nil {
-			return nil, fmt.Errorf("error reading file: %w", err)
-		}
-
-		return tasks, nil
-	})
-}
@@ -3,16 +3,29 @@ package output
 import (
 	"fmt"
 	"io"
+	"iter"
+	"slices"
 
-	"github.com/IlyasYOY/monotask/internal/pkg/extractor"
+	"github.com/IlyasYOY/monotask/extractor"
 )
 
 func PrintGNUFormatTo(tasks []extractor.Task, writer io.Writer) {
-	for _, task := range tasks {
+	StreamGNUFormatTo(slices.Values(tasks), writer)
+}
+
+// StreamGNUFormatTo prints every task as soon as the sequence yields it.
+func StreamGNUFormatTo(tasks iter.Seq[extractor.Task], writer io.Writer) {
+	for task := range tasks {
+		message := task.Message
+		// Notebook tasks are on lines of the notebook JSON, the cell helps
+		// to find them in the notebook.
+		if task.Cell != 0 {
+			message += fmt.Sprintf(" (cell %d, line %d)", task.Cell, task.CellLine)
+		}
 		if task.Assignee != "" {
-			fmt.Fprintf(writer, "%s:%d:%d: %s(%s): %s\n", task.File, task.Line, task.Column, task.Type, task.Assignee, task.Message)
+			fmt.Fprintf(writer, "%s:%d:%d: %s(%s): %s\n", task.File, task.Line, task.Column, task.Type, task.Assignee, message)
 		} else {
-			fmt.Fprintf(writer, "%s:%d:%d: %s: %s\n", task.File, task.Line, task.Column, task.Type, task.Message)
+			fmt.Fprintf(writer, "%s:%d:%d: %s: %s\n", task.File, task.Line, task.Column, task.Type, message)
 		}
 	}
 }
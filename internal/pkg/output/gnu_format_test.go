@@ -4,7 +4,7 @@ import (
 	"bytes"
 	"testing"
 
-	"github.com/IlyasYOY/monotask/internal/pkg/extractor"
+	"github.com/IlyasYOY/monotask/extractor"
 	"github.com/IlyasYOY/monotask/internal/pkg/output"
 	"github.com/google/go-cmp/cmp"
 )
@@ -58,6 +58,13 @@ func TestPrintGNUFormatTo(t *testing.T) {
 			},
 			expected: "main.go:10:5: TODO(user1): fix bug\nutils.go:25:12: BUG: handle error\n",
 		},
+		{
+			name: "notebook task",
+			tasks: []extractor.Task{
+				{File: "analysis.ipynb", Line: 14, Column: 1, Cell: 2, CellLine: 3, Type: "TODO", Message: "plot results"},
+			},
+			expected: "analysis.ipynb:14:1: TODO: plot results (cell 2, line 3)\n",
+		},
 	}
 
 	for _, tt := range tests {
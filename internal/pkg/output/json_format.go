@@ -0,0 +1,76 @@
+package output
+
+import (
+	"encoding/json"
+	"io"
+	"iter"
+	"slices"
+
+	"github.com/IlyasYOY/monotask/extractor"
+)
+
+// JSONSchemaVersion is the version of the task object emitted by
+// [PrintJSONTo] and [PrintNDJSONTo].
+//
+// It is bumped whenever a field is renamed, removed or changes its meaning.
+// Adding a new field does not bump the version.
+const JSONSchemaVersion = 1
+
+// jsonTask is the stable JSON representation of [extractor.Task].
+type jsonTask struct {
+	SchemaVersion int    `json:"schemaVersion"`
+	File          string `json:"file"`
+	Line          int    `json:"line"`
+	EndLine       int    `json:"endLine"`
+	Column        int    `json:"column"`
+	Cell          int    `json:"cell,omitempty"`
+	CellLine      int    `json:"cellLine,omitempty"`
+	Type          string `json:"type"`
+	Assignee      string `json:"assignee"`
+	Message       string `json:"message"`
+}
+
+func toJSONTask(task extractor.Task) jsonTask {
+	return jsonTask{
+		SchemaVersion: JSONSchemaVersion,
+		File:          task.File,
+		Line:          task.Line,
+		EndLine:       max(task.EndLine, task.Line),
+		Column:        task.Column,
+		Cell:          task.Cell,
+		CellLine:      task.CellLine,
+		Type:          task.Type,
+		Assignee:      task.Assignee,
+		Message:       task.Message,
+	}
+}
+
+// PrintJSONTo writes tasks as a single JSON array.
+func PrintJSONTo(tasks []extractor.Task, writer io.Writer) error {
+	jsonTasks := make([]jsonTask, 0, len(tasks))
+	for _, task := range tasks {
+		jsonTasks = append(jsonTasks, toJSONTask(task))
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(jsonTasks)
+}
+
+// PrintNDJSONTo writes tasks as newline delimited JSON: one object per line.
+func PrintNDJSONTo(tasks []extractor.Task, writer io.Writer) error {
+	return StreamNDJSONTo(slices.Values(tasks), writer)
+}
+
+// StreamNDJSONTo writes every task as soon as the sequence yields it.
+func StreamNDJSONTo(tasks iter.Seq[extractor.Task], writer io.Writer) error {
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	for task := range tasks {
+		if err := encoder.Encode(toJSONTask(task)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
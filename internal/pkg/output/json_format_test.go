@@ -0,0 +1,146 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/IlyasYOY/monotask/extractor"
+	"github.com/IlyasYOY/monotask/internal/pkg/output"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrintJSONTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []extractor.Task
+		expected string
+	}{
+		{
+			name:     "empty tasks",
+			tasks:    []extractor.Task{},
+			expected: "[]\n",
+		},
+		{
+			name: "task with assignee",
+			tasks: []extractor.Task{
+				{File: "main.go", Line: 10, Column: 5, Type: "TODO", Assignee: "IlyasYOY", Message: "fix: the bug"},
+			},
+			expected: `[
+  {
+    "schemaVersion": 1,
+    "file": "main.go",
+    "line": 10,
+    "endLine": 10,
+    "column": 5,
+    "type": "TODO",
+    "assignee": "IlyasYOY",
+    "message": "fix: the bug"
+  }
+]
+`,
+		},
+		{
+			name: "multiple tasks",
+			tasks: []extractor.Task{
+				{File: "main.go", Line: 10, Column: 5, Type: "TODO", Message: "a < b"},
+				{File: "tasks.md", Line: 1, Column: 1, Type: "CHECKBOX", Message: "write docs"},
+			},
+			expected: `[
+  {
+    "schemaVersion": 1,
+    "file": "main.go",
+    "line": 10,
+    "endLine": 10,
+    "column": 5,
+    "type": "TODO",
+    "assignee": "",
+    "message": "a < b"
+  },
+  {
+    "schemaVersion": 1,
+    "file": "tasks.md",
+    "line": 1,
+    "endLine": 1,
+    "column": 1,
+    "type": "CHECKBOX",
+    "assignee": "",
+    "message": "write docs"
+  }
+]
+`,
+		},
+		{
+			name: "notebook task",
+			tasks: []extractor.Task{
+				{File: "analysis.ipynb", Line: 14, EndLine: 15, Column: 1, Cell: 2, CellLine: 3, Type: "TODO", Message: "plot results"},
+			},
+			expected: `[
+  {
+    "schemaVersion": 1,
+    "file": "analysis.ipynb",
+    "line": 14,
+    "endLine": 15,
+    "column": 1,
+    "cell": 2,
+    "cellLine": 3,
+    "type": "TODO",
+    "assignee": "",
+    "message": "plot results"
+  }
+]
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := output.PrintJSONTo(tt.tasks, &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, buf.String()); diff != "" {
+				t.Errorf("(-want +got):\\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPrintNDJSONTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []extractor.Task
+		expected string
+	}{
+		{
+			name:     "empty tasks",
+			tasks:    []extractor.Task{},
+			expected: "",
+		},
+		{
+			name: "multiple tasks",
+			tasks: []extractor.Task{
+				{File: "main.go", Line: 10, Column: 5, Type: "TODO", Assignee: "user1", Message: "fix: bug"},
+				{File: "utils.go", Line: 25, Column: 12, Type: "BUG", Message: "handle \"error\""},
+			},
+			expected: `{"schemaVersion":1,"file":"main.go","line":10,"endLine":10,"column":5,"type":"TODO","assignee":"user1","message":"fix: bug"}
+{"schemaVersion":1,"file":"utils.go","line":25,"endLine":25,"column":12,"type":"BUG","assignee":"","message":"handle \"error\""}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := output.PrintNDJSONTo(tt.tasks, &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, buf.String()); diff != "" {
+				t.Errorf("(-want +got):\\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package output
+
+import (
+	"bufio"
+	"encoding/json"
+	"io"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"unicode/utf16"
+
+	"github.com/IlyasYOY/monotask/extractor"
+)
+
+const (
+	sarifVersion = "2.1.0"
+	sarifSchema  = "https://json.schemastore.org/sarif-2.1.0.json"
+	// sarifColumnKind is the unit of columns, the one GitHub code scanning
+	// expects.
+	sarifColumnKind = "utf16CodeUnits"
+)
+
+type sarifLog struct {
+	Version string     `json:"version"`
+	Schema  string     `json:"$schema"`
+	Runs    []sarifRun `json:"runs"`
+}
+
+type sarifRun struct {
+	Tool       sarifTool     `json:"tool"`
+	ColumnKind string        `json:"columnKind"`
+	Results    []sarifResult `json:"results"`
+}
+
+type sarifTool struct {
+	Driver sarifDriver `json:"driver"`
+}
+
+type sarifDriver struct {
+	Name           string      `json:"name"`
+	InformationURI string      `json:"informationUri"`
+	Rules          []sarifRule `json:"rules"`
+}
+
+type sarifRule struct {
+	ID                   string              `json:"id"`
+	ShortDescription     sarifMessage        `json:"shortDescription"`
+	DefaultConfiguration sarifRuleConfigured `json:"defaultConfiguration"`
+}
+
+type sarifRuleConfigured struct {
+	Level string `json:"level"`
+}
+
+type sarifMessage struct {
+	Text string `json:"text"`
+}
+
+type sarifResult struct {
+	RuleID     string            `json:"ruleId"`
+	RuleIndex  int               `json:"ruleIndex"`
+	Level      string            `json:"level"`
+	Message    sarifMessage      `json:"message"`
+	Locations  []sarifLocation   `json:"locations"`
+	Properties map[string]string `json:"properties,omitempty"`
+}
+
+type sarifLocation struct {
+	PhysicalLocation sarifPhysicalLocation `json:"physicalLocation"`
+}
+
+type sarifPhysicalLocation struct {
+	ArtifactLocation sarifArtifactLocation `json:"artifactLocation"`
+	Region           sarifRegion           `json:"region"`
+}
+
+type sarifArtifactLocation struct {
+	URI string `json:"uri"`
+}
+
+type sarifRegion struct {
+	StartLine   int `json:"startLine"`
+	StartColumn int `json:"startColumn"`
+	// EndLine is omitted for single-line tasks.
+	EndLine int `json:"endLine,omitempty"`
+}
+
+// PrintSARIFTo writes tasks as a SARIF 2.1.0 log with a single run.
+//
+// Every marker type becomes a rule, rules are listed in order of the first
+// appearance of the type. Columns of tasks count bytes, they are converted
+// to UTF-16 code units using lines of the task files.
+func PrintSARIFTo(tasks []extractor.Task, writer io.Writer) error {
+	rules := []sarifRule{}
+	ruleIndexes := make(map[string]int)
+	results := make([]sarifResult, 0, len(tasks))
+	columns := sarifColumns{}
+
+	for _, task := range tasks {
+		level := sarifLevel(task.Type)
+		ruleIndex, ok := ruleIndexes[task.Type]
+		if !ok {
+			ruleIndex = len(rules)
+			ruleIndexes[task.Type] = ruleIndex
+			rules = append(rules, sarifRule{
+				ID:                   task.Type,
+				ShortDescription:     sarifMessage{Text: task.Type + " marker"},
+				DefaultConfiguration: sarifRuleConfigured{Level: level},
+			})
+		}
+
+		var properties map[string]string
+		if task.Assignee != "" {
+			properties = map[string]string{"assignee": task.Assignee}
+		}
+
+		results = append(results, sarifResult{
+			RuleID:    task.Type,
+			RuleIndex: ruleIndex,
+			Level:     level,
+			Message:   sarifMessage{Text: task.Message},
+			Locations: []sarifLocation{{
+				PhysicalLocation: sarifPhysicalLocation{
+					ArtifactLocation: sarifArtifactLocation{URI: sarifURI(task.File)},
+					Region: sarifRegion{
+						StartLine:   task.Line,
+						StartColumn: columns.column(task),
+						EndLine:     sarifEndLine(task),
+					},
+				},
+			}},
+			Properties: properties,
+		})
+	}
+
+	log := sarifLog{
+		Version: sarifVersion,
+		Schema:  sarifSchema,
+		Runs: []sarifRun{{
+			Tool: sarifTool{Driver: sarifDriver{
+				Name:           "monotask",
+				InformationURI: "https://github.com/IlyasYOY/monotask",
+				Rules:          rules,
+			}},
+			ColumnKind: sarifColumnKind,
+			Results:    results,
+		}},
+	}
+
+	encoder := json.NewEncoder(writer)
+	encoder.SetEscapeHTML(false)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(log)
+}
+
+// sarifLevel reports bugs as warnings, everything else is a note.
+func sarifLevel(typ string) string {
+	if typ == "BUG" {
+		return "warning"
+	}
+	return "note"
+}
+
+// sarifURI turns absolute paths into file URIs, relative paths are kept
+// relative so they resolve against the checkout root.
+func sarifURI(path string) string {
+	if !filepath.IsAbs(path) {
+		return (&url.URL{Path: filepath.ToSlash(path)}).String()
+	}
+	path = filepath.ToSlash(path)
+	// Windows paths start with a volume name: C:/path.
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+	return (&url.URL{Scheme: "file", Path: path}).String()
+}
+
+func sarifEndLine(task extractor.Task) int {
+	if task.EndLine > task.Line {
+		return task.EndLine
+	}
+	return 0
+}
+
+// sarifColumns converts byte columns of tasks to UTF-16 code units, lines of
+// every file are read once.
+type sarifColumns map[string][]string
+
+func (c sarifColumns) column(task extractor.Task) int {
+	lines, ok := c[task.File]
+	if !ok {
+		lines = readLines(task.File)
+		c[task.File] = lines
+	}
+	if task.Line < 1 || task.Line > len(lines) {
+		return task.Column
+	}
+
+	line := lines[task.Line-1]
+	if task.Column < 1 || task.Column > len(line)+1 {
+		return task.Column
+	}
+	return len(utf16.Encode([]rune(line[:task.Column-1]))) + 1
+}
+
+// readLines returns lines of the file, nil if it cannot be read: columns of
+// its tasks are kept as is.
+func readLines(path string) []string {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil
+	}
+	defer file.Close()
+
+	var lines []string
+	scanner := bufio.NewScanner(file)
+	scanner.Buffer(nil, 1024*1024)
+	for scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
@@ -0,0 +1,168 @@
+package output_test
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/IlyasYOY/monotask/extractor"
+	"github.com/IlyasYOY/monotask/internal/pkg/output"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPrintSARIFTo(t *testing.T) {
+	tests := []struct {
+		name     string
+		tasks    []extractor.Task
+		expected string
+	}{
+		{
+			name:  "empty tasks",
+			tasks: []extractor.Task{},
+			expected: `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "monotask",
+          "informationUri": "https://github.com/IlyasYOY/monotask",
+          "rules": []
+        }
+      },
+      "columnKind": "utf16CodeUnits",
+      "results": []
+    }
+  ]
+}
+`,
+		},
+		{
+			name: "rule per marker type",
+			tasks: []extractor.Task{
+				{File: "/work/main.go", Line: 10, Column: 5, Type: "BUG", Assignee: "user", Message: "crash"},
+				{File: "docs/tasks.md", Line: 1, Column: 1, Type: "CHECKBOX", Message: "write docs"},
+				{File: "/work/main.go", Line: 12, EndLine: 13, Column: 5, Type: "BUG", Message: "another crash"},
+			},
+			expected: `{
+  "version": "2.1.0",
+  "$schema": "https://json.schemastore.org/sarif-2.1.0.json",
+  "runs": [
+    {
+      "tool": {
+        "driver": {
+          "name": "monotask",
+          "informationUri": "https://github.com/IlyasYOY/monotask",
+          "rules": [
+            {
+              "id": "BUG",
+              "shortDescription": {
+                "text": "BUG marker"
+              },
+              "defaultConfiguration": {
+                "level": "warning"
+              }
+            },
+            {
+              "id": "CHECKBOX",
+              "shortDescription": {
+                "text": "CHECKBOX marker"
+              },
+              "defaultConfiguration": {
+                "level": "note"
+              }
+            }
+          ]
+        }
+      },
+      "columnKind": "utf16CodeUnits",
+      "results": [
+        {
+          "ruleId": "BUG",
+          "ruleIndex": 0,
+          "level": "warning",
+          "message": {
+            "text": "crash"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "file:///work/main.go"
+                },
+                "region": {
+                  "startLine": 10,
+                  "startColumn": 5
+                }
+              }
+            }
+          ],
+          "properties": {
+            "assignee": "user"
+          }
+        },
+        {
+          "ruleId": "CHECKBOX",
+          "ruleIndex": 1,
+          "level": "note",
+          "message": {
+            "text": "write docs"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "docs/tasks.md"
+                },
+                "region": {
+                  "startLine": 1,
+                  "startColumn": 1
+                }
+              }
+            }
+          ]
+        },
+        {
+          "ruleId": "BUG",
+          "ruleIndex": 0,
+          "level": "warning",
+          "message": {
+            "text": "another crash"
+          },
+          "locations": [
+            {
+              "physicalLocation": {
+                "artifactLocation": {
+                  "uri": "file:///work/main.go"
+                },
+                "region": {
+                  "startLine": 12,
+                  "startColumn": 5,
+                  "endLine": 13
+                }
+              }
+            }
+          ]
+        }
+      ]
+    }
+  ]
+}
+`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			if err := output.PrintSARIFTo(tt.tasks, &buf); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if diff := cmp.Diff(tt.expected, buf.String()); diff != "" {
+				t.Errorf("(-want +got):\\n%s", diff)
+			}
+		})
+	}
+}